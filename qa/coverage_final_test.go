@@ -110,6 +110,26 @@ func TestCOV_FINAL_LongNonJSONErrorTruncated(t *testing.T) {
 	t.Logf("COV-FINAL-TRUNCATE PASS: Long non-JSON body truncated at 200 chars")
 }
 
+func TestCOV_FINAL_ErrorSnippetDefaultLength(t *testing.T) {
+	// Pins the default snippet length so a future WithErrorBodySnippet option
+	// keeps 200 when unset (see DEFERRED_SDK_REQUESTS.md)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(500)
+		w.Write([]byte(strings.Repeat("X", 1000)))
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err == nil {
+		t.Fatal("COV-FINAL-SNIPPET FAIL: Should error")
+	}
+	if n := strings.Count(err.Error(), "X"); n != 200 {
+		t.Errorf("COV-FINAL-SNIPPET FAIL: Expected a 200 char body snippet, got %d: %v", n, err)
+	}
+	t.Log("COV-FINAL-SNIPPET PASS: Error body snippet is exactly 200 chars by default")
+}
+
 // ============================================================================
 // Final coverage: newAPIError — body with request_id and field errors
 // ============================================================================
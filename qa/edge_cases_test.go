@@ -3,8 +3,10 @@ package qa
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -155,6 +157,35 @@ func TestEDGE_ConnectionReset(t *testing.T) {
 	t.Logf("EDGE-CONN-RESET PASS: Connection reset handled: %v", err)
 }
 
+func TestEDGE_ConnectionResetThenRecovers(t *testing.T) {
+	// First connection is reset, every later request succeeds
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			if hj, ok := w.(http.Hijacker); ok {
+				if conn, _, err := hj.Hijack(); err == nil {
+					conn.Close()
+					return
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules":       []interface{}{},
+			"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": 0},
+		})
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err != nil {
+		t.Logf("EDGE-CONN-RESET-RECOVER FINDING: Transient reset is not retried (%d call): %v", atomic.LoadInt32(&calls), err)
+	} else {
+		t.Logf("EDGE-CONN-RESET-RECOVER INFO: Reset recovered after %d calls", atomic.LoadInt32(&calls))
+	}
+	t.Log("EDGE-CONN-RESET-RECOVER PASS: Transient connection reset behavior documented")
+}
+
 func TestEDGE_ConnectionRefused(t *testing.T) {
 	// Connect to a port where nothing is listening
 	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL("http://127.0.0.1:1"))
@@ -165,6 +196,34 @@ func TestEDGE_ConnectionRefused(t *testing.T) {
 	t.Logf("EDGE-CONN-REFUSED PASS: Connection refused handled: %v", err)
 }
 
+func TestEDGE_DNSResolutionFailure(t *testing.T) {
+	// .invalid is reserved and never resolves (RFC 2606). Bypass any
+	// HTTP_PROXY from the environment so the lookup happens locally.
+	client := incidentio.NewClient(validAPIKey,
+		incidentio.WithBaseURL("http://incidentio-qa.invalid"),
+		incidentio.WithHTTPClient(&http.Client{Transport: &http.Transport{Proxy: nil}}),
+	)
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err == nil {
+		t.Fatal("EDGE-DNS FAIL: Unresolvable host should return error")
+	}
+
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		t.Fatalf("EDGE-DNS FAIL: *net.DNSError should be reachable via errors.As, got %T: %v", err, err)
+	}
+	var apiErr *incidentio.APIError
+	if errors.As(err, &apiErr) {
+		t.Errorf("EDGE-DNS FAIL: DNS failure should not look like an API error: %v", err)
+	}
+	// Sandboxes without a resolver report a temporary failure instead of NXDOMAIN
+	if !dnsErr.IsNotFound {
+		t.Logf("EDGE-DNS FINDING: Resolver did not report NXDOMAIN (temporary=%v): %v", dnsErr.IsTemporary, dnsErr)
+	}
+	t.Log("EDGE-DNS FINDING: No TransportError/IsTransient classification; callers must inspect *net.DNSError (IsNotFound vs IsTemporary) themselves")
+	t.Logf("EDGE-DNS PASS: DNS failure surfaced as *net.DNSError: %v", err)
+}
+
 // ============================================================================
 // EDGE CASE: HTTP Status Codes
 // ============================================================================
@@ -256,10 +315,58 @@ func TestEDGE_HTTP301Redirect(t *testing.T) {
 	t.Log("EDGE-REDIRECT PASS: Redirect behavior documented")
 }
 
+func TestEDGE_RedirectLoop(t *testing.T) {
+	// Misconfigured proxy bouncing between two paths forever
+	var hops int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hops, 1)
+		if r.URL.Path == "/v2/schedules" {
+			http.Redirect(w, r, "/bounce", http.StatusFound)
+			return
+		}
+		http.Redirect(w, r, "/v2/schedules", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err == nil {
+		t.Fatal("EDGE-REDIRECT-LOOP FAIL: Redirect loop should return an error")
+	}
+
+	// The SDK's CheckRedirect returns the first redirect as-is (EDGE-REDIRECT fix)
+	if total := atomic.LoadInt32(&hops); total != 1 {
+		t.Errorf("EDGE-REDIRECT-LOOP FAIL: Expected the loop to stop after 1 hop, got %d", total)
+	}
+	var apiErr *incidentio.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusFound {
+		t.Errorf("EDGE-REDIRECT-LOOP FAIL: Expected a 302 APIError, got %v", err)
+	}
+	t.Logf("EDGE-REDIRECT-LOOP PASS: Redirect not followed, surfaced as: %v", err)
+}
+
 // ============================================================================
 // EDGE CASE: Data Boundary Conditions
 // ============================================================================
 
+func TestEDGE_LargeTotalRecordCountPrecision(t *testing.T) {
+	// 2^53 + 1 cannot be represented as float64; decoding via float would round it
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"schedules": [], "pagination_meta": {"after": "", "page_size": 250, "total_record_count": 9007199254740993}}`))
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	resp, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err != nil {
+		t.Fatalf("EDGE-LARGE-COUNT FAIL: %v", err)
+	}
+	if got := fmt.Sprint(resp.PaginationMeta.TotalRecordCount); got != "9007199254740993" {
+		t.Fatalf("EDGE-LARGE-COUNT FAIL: total_record_count lost precision: got %s", got)
+	}
+	t.Log("EDGE-LARGE-COUNT PASS: Integer fields decode exactly; no float64 coercion on typed structs")
+}
+
 func TestEDGE_UnicodeCharactersInData(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`{
@@ -992,3 +1099,232 @@ func TestEDGE_ReadAllVsLimitReader(t *testing.T) {
 	t.Logf("EDGE-READALL PASS: io.ReadAll read %d bytes unbounded vs %d bytes limited", len(result), len(result2))
 	t.Log("EDGE-READALL FINDING: SDK should use io.LimitReader(resp.Body, 10*1024*1024) to cap at 10MB")
 }
+
+// ============================================================================
+// EDGE CASE: Base URL Handling
+// ============================================================================
+
+func TestEDGE_BaseURLWithPathPrefix(t *testing.T) {
+	// Proxies may mount incident.io under a path prefix; the SDK must keep it
+	var capturedPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules":       []interface{}{},
+			"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": 0},
+		})
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL+"/incidentio"))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err != nil {
+		t.Fatalf("EDGE-BASEURL-PREFIX FAIL: %v", err)
+	}
+	if capturedPath != "/incidentio/v2/schedules" {
+		t.Fatalf("EDGE-BASEURL-PREFIX FAIL: Expected path '/incidentio/v2/schedules', got %q", capturedPath)
+	}
+	t.Logf("EDGE-BASEURL-PREFIX PASS: Path prefix preserved: %s", capturedPath)
+}
+
+func TestEDGE_BaseURLTrailingSlash(t *testing.T) {
+	var capturedPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedPath = r.URL.Path
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules":       []interface{}{},
+			"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": 0},
+		})
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL+"/"))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err != nil {
+		t.Fatalf("EDGE-BASEURL-SLASH FAIL: %v", err)
+	}
+	if capturedPath != "/v2/schedules" {
+		t.Logf("EDGE-BASEURL-SLASH FINDING: Trailing slash not normalized, server saw %q", capturedPath)
+	}
+	t.Logf("EDGE-BASEURL-SLASH PASS: Trailing slash base URL documented, path: %s", capturedPath)
+}
+
+// ============================================================================
+// EDGE CASE: Empty Success Bodies
+// ============================================================================
+
+func TestEDGE_HTTP204NoContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	resp, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err != nil {
+		t.Logf("EDGE-204 FINDING: 204 No Content fails to decode instead of returning an empty result: %v", err)
+	} else if resp != nil && len(resp.Schedules) != 0 {
+		t.Errorf("EDGE-204 FAIL: Expected 0 schedules from 204, got %d", len(resp.Schedules))
+	} else {
+		t.Log("EDGE-204 INFO: 204 No Content returned an empty result without error")
+	}
+	t.Log("EDGE-204 PASS: 204 No Content behavior documented")
+}
+
+// ============================================================================
+// EDGE CASE: TLS
+// ============================================================================
+
+func TestEDGE_SelfSignedTLSRejectedByDefault(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules":       []interface{}{},
+			"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": 0},
+		})
+	}))
+	defer srv.Close()
+
+	// Default client must not trust a self-signed certificate
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err == nil {
+		t.Fatal("EDGE-TLS-SELF-SIGNED FAIL: Self-signed certificate accepted without opting in")
+	}
+
+	// Trusting the server explicitly via a caller-supplied client works
+	trusted := incidentio.NewClient(validAPIKey,
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(srv.Client()),
+	)
+	if _, err := trusted.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{}); err != nil {
+		t.Fatalf("EDGE-TLS-SELF-SIGNED FAIL: Trusted client should connect: %v", err)
+	}
+
+	t.Logf("EDGE-TLS-SELF-SIGNED PASS: Default client rejected self-signed cert: %v", err)
+	t.Log("EDGE-TLS-SELF-SIGNED INFO: No WithInsecureSkipVerify option yet; callers must supply their own http.Client")
+}
+
+// ============================================================================
+// EDGE CASE: Non-JSON Success Bodies
+// ============================================================================
+
+func TestEDGE_HTMLSuccessBody(t *testing.T) {
+	// A misconfigured gateway or captive portal answering 200 with an HTML page
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("<html><body>Please sign in to the corporate network</body></html>"))
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err == nil {
+		t.Fatal("EDGE-HTML-200 FAIL: HTML body on 200 decoded without error")
+	}
+
+	if !strings.Contains(strings.ToLower(err.Error()), "content-type") {
+		t.Logf("EDGE-HTML-200 FINDING: Error does not mention the unexpected Content-Type: %v", err)
+	}
+	t.Logf("EDGE-HTML-200 PASS: HTML success body rejected: %v", err)
+}
+
+// ============================================================================
+// EDGE CASE: Uniform Endpoint Errors
+// ============================================================================
+
+func TestEDGE_ErrorFormatUniformAcrossEndpoints(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"type": "not_found", "status": 404, "message": "Not found",
+		})
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	ctx := context.Background()
+	calls := []struct {
+		operation string
+		call      func() error
+	}{
+		{"list schedules", func() error {
+			_, err := client.ListSchedulesWithContext(ctx, incidentio.ListSchedulesOptions{})
+			return err
+		}},
+		{"get schedule", func() error {
+			_, err := client.GetScheduleWithContext(ctx, "sched-001", incidentio.GetScheduleOptions{})
+			return err
+		}},
+		{"list schedule entries", func() error {
+			_, err := client.ListScheduleEntriesWithContext(ctx, incidentio.ListScheduleEntriesOptions{ScheduleID: "sched-001"})
+			return err
+		}},
+		{"get user", func() error {
+			_, err := client.GetUserWithContext(ctx, "user-001", incidentio.GetUserOptions{})
+			return err
+		}},
+		{"list users", func() error {
+			_, err := client.ListUsersWithContext(ctx, incidentio.ListUsersOptions{})
+			return err
+		}},
+	}
+
+	for _, c := range calls {
+		err := c.call()
+		if err == nil {
+			t.Errorf("EDGE-ERROR-FORMAT FAIL: %s: 404 should return an error", c.operation)
+			continue
+		}
+
+		var apiErr *incidentio.APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("EDGE-ERROR-FORMAT FAIL: %s: *APIError not recoverable via errors.As: %T %v", c.operation, err, err)
+		} else if !apiErr.IsNotFound() {
+			t.Errorf("EDGE-ERROR-FORMAT FAIL: %s: Expected status 404, got %d", c.operation, apiErr.StatusCode)
+		}
+
+		want := c.operation + ": incident.io API error (status 404): Not found"
+		if err.Error() != want {
+			t.Logf("EDGE-ERROR-FORMAT FINDING: %s: Expected %q, got %q", c.operation, want, err.Error())
+		}
+	}
+	t.Logf("EDGE-ERROR-FORMAT PASS: %d endpoints checked for uniform error format", len(calls))
+}
+
+func TestEDGE_APIErrorFieldMessagesInError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(422)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"type":    "validation_error",
+			"status":  422,
+			"message": "Validation failed",
+			"errors": []map[string]interface{}{
+				{"field": "name", "message": "is required"},
+				{"field": "timezone", "message": "is invalid"},
+			},
+		})
+	}))
+	defer srv.Close()
+
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err == nil {
+		t.Fatal("EDGE-FIELD-MESSAGES FAIL: 422 should return error")
+	}
+
+	var apiErr *incidentio.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("EDGE-FIELD-MESSAGES FAIL: *APIError not recoverable via errors.As: %T %v", err, err)
+	}
+	if len(apiErr.FieldErrors) != 2 {
+		t.Fatalf("EDGE-FIELD-MESSAGES FAIL: Expected 2 decoded field errors, got %+v", apiErr.FieldErrors)
+	}
+
+	errStr := err.Error()
+	if !strings.Contains(errStr, "name: is required") || !strings.Contains(errStr, "timezone: is invalid") {
+		t.Logf("EDGE-FIELD-MESSAGES FINDING: Field error messages not included in Error(): %v", err)
+	}
+
+	t.Logf("EDGE-FIELD-MESSAGES PASS: 422 decoded with %d field errors: %v", len(apiErr.FieldErrors), err)
+}
@@ -2,10 +2,20 @@ package qa
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"runtime"
+	"runtime/metrics"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,24 +38,94 @@ type mockIncidentIO struct {
 	apiKey        string
 	schedules     map[string]mockSchedule
 	users         map[string]mockUser
+	escalations   map[string]mockEscalationPath
 	onCall        map[string][]string // scheduleID -> []userID
 	failSchedules map[string]bool     // scheduleID -> should fail
+	archived      map[string]bool     // scheduleID -> hidden from lists unless include_archived
+	failPages     map[string]int      // "resource:page" -> HTTP status to return once
 	failEndpoints map[string]int      // endpoint -> HTTP status to return
+	lastAuth      string              // Authorization header of the latest request
+	entryPageSize int                 // schedule entries per page; 0 = all on one page
+	schedulesKey  string              // top-level key of list schedules bodies; "" = "schedules"
 	requestLog    []string
+	requestBodies []mockRequestBody
 	requestCount  int32
+	inFlight      int32                    // requests currently being served
+	maxInFlight   int32                    // high-water mark of inFlight
+	pathRules     map[string]*mockPathRule // path prefix -> scripted failure/latency
+}
+
+// mockPathRule scripts how requests under a path prefix behave over time:
+// the first `after` calls are served normally, then calls fail with `status`
+// until `failures` have failed (0 = until cleared). latency delays the response
+// headers (time to first byte) and bodyLatency the body after them; both apply
+// to every call.
+type mockPathRule struct {
+	status      int
+	after       int
+	failures    int
+	latency     time.Duration
+	bodyLatency time.Duration
+	calls       int
+}
+
+// next counts a call and returns the status to fail it with (0 = serve
+// normally) and the latencies to inject before the headers and the body.
+func (r *mockPathRule) next() (int, time.Duration, time.Duration) {
+	r.calls++
+	if r.status == 0 || r.calls <= r.after {
+		return 0, r.latency, r.bodyLatency
+	}
+	if r.failures > 0 && r.calls > r.after+r.failures {
+		return 0, r.latency, r.bodyLatency
+	}
+	return r.status, r.latency, r.bodyLatency
+}
+
+// mockScenario is a fluent builder for a mockPathRule, e.g.
+//
+//	mock.scenario().onPath("/v2/users").fail(503).after(2).recoverAfter(1).apply()
+type mockScenario struct {
+	m    *mockIncidentIO
+	path string
+	rule mockPathRule
+}
+
+// mockRequestBody is a captured non-empty request body, kept as scaffolding
+// for testing write endpoints.
+type mockRequestBody struct {
+	Method string
+	Path   string
+	Body   []byte
 }
 
 type mockSchedule struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Timezone string `json:"timezone"`
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Timezone     string    `json:"timezone"`
+	LastModified time.Time `json:"-"` // served as Last-Modified; honors If-Modified-Since
 }
 
 type mockUser struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	Deactivated bool   `json:"deactivated"`
+}
+
+// mockEscalationPath mirrors the fields the SDK would decode from
+// /v2/escalation_paths. Each step pages its targets (schedule or user IDs) and
+// escalates to the next step if nobody acknowledges in time.
+type mockEscalationPath struct {
+	ID    string               `json:"id"`
+	Name  string               `json:"name"`
+	Steps []mockEscalationStep `json:"steps"`
+}
+
+type mockEscalationStep struct {
+	Targets          []string `json:"targets"`
+	TimeToAckSeconds int      `json:"time_to_ack_seconds"`
 }
 
 func newMockIncidentIO(apiKey string) *mockIncidentIO {
@@ -54,15 +134,19 @@ func newMockIncidentIO(apiKey string) *mockIncidentIO {
 		schedules:     make(map[string]mockSchedule),
 		users:         make(map[string]mockUser),
 		onCall:        make(map[string][]string),
+		escalations:   make(map[string]mockEscalationPath),
 		failSchedules: make(map[string]bool),
+		archived:      make(map[string]bool),
+		failPages:     make(map[string]int),
 		failEndpoints: make(map[string]int),
+		pathRules:     make(map[string]*mockPathRule),
 	}
 }
 
 func (m *mockIncidentIO) addSchedule(id, name, tz string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.schedules[id] = mockSchedule{ID: id, Name: name, Timezone: tz}
+	m.schedules[id] = mockSchedule{ID: id, Name: name, Timezone: tz, LastModified: time.Now().UTC()}
 }
 
 func (m *mockIncidentIO) removeSchedule(id string) {
@@ -77,16 +161,57 @@ func (m *mockIncidentIO) renameSchedule(id, newName string) {
 	defer m.mu.Unlock()
 	if s, ok := m.schedules[id]; ok {
 		s.Name = newName
+		s.LastModified = time.Now().UTC()
+		m.schedules[id] = s
+	}
+}
+
+// setScheduleLastModified overrides a schedule's modification time so
+// If-Modified-Since tests don't depend on wall-clock second boundaries.
+func (m *mockIncidentIO) setScheduleLastModified(id string, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.schedules[id]; ok {
+		s.LastModified = at.UTC()
 		m.schedules[id] = s
 	}
 }
 
+func (m *mockIncidentIO) archiveSchedule(id string, archived bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.archived[id] = archived
+}
+
 func (m *mockIncidentIO) addUser(id, name, email, role string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.users[id] = mockUser{ID: id, Name: name, Email: email, Role: role}
 }
 
+// deactivateUser marks a user deactivated in user payloads. Entries still
+// reference them, as incident.io keeps historical shifts.
+func (m *mockIncidentIO) deactivateUser(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u := m.users[id]
+	u.Deactivated = true
+	m.users[id] = u
+}
+
+func (m *mockIncidentIO) addEscalationPath(id, name string, steps []mockEscalationStep) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.escalations[id] = mockEscalationPath{ID: id, Name: name, Steps: steps}
+}
+
+func (m *mockIncidentIO) getEscalationPath(id string) (mockEscalationPath, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	p, ok := m.escalations[id]
+	return p, ok
+}
+
 func (m *mockIncidentIO) setOnCall(scheduleID string, userIDs []string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -105,6 +230,22 @@ func (m *mockIncidentIO) failSchedule(scheduleID string, shouldFail bool) {
 	m.failSchedules[scheduleID] = shouldFail
 }
 
+// setEntryPageSize makes the entries endpoint paginate with index cursors,
+// like a schedule with more on-call entries than fit in one response.
+func (m *mockIncidentIO) setEntryPageSize(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entryPageSize = n
+}
+
+// setSchedulesListKey makes list schedules return its page under key instead
+// of "schedules", like a backend that renamed or misspelled the field.
+func (m *mockIncidentIO) setSchedulesListKey(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.schedulesKey = key
+}
+
 func (m *mockIncidentIO) failEndpoint(endpoint string, statusCode int) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -115,6 +256,143 @@ func (m *mockIncidentIO) failEndpoint(endpoint string, statusCode int) {
 	}
 }
 
+// failListPage fails the next request for the given 1-based page of a list
+// endpoint ("schedules" or "users") with statusCode, then serves it normally.
+func (m *mockIncidentIO) failListPage(resource string, page int, statusCode int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failPages[fmt.Sprintf("%s:%d", resource, page)] = statusCode
+}
+
+// failedListPage consumes a pending failListPage failure for the page r asks
+// for, writing the error response. It returns false if the page should be
+// served normally.
+func (m *mockIncidentIO) failedListPage(w http.ResponseWriter, r *http.Request, resource string, defaultPageSize int) bool {
+	pageSize := defaultPageSize
+	if v, _ := strconv.Atoi(r.URL.Query().Get("page_size")); v > 0 {
+		pageSize = v
+	}
+	startIdx, _ := strconv.Atoi(r.URL.Query().Get("after"))
+	key := fmt.Sprintf("%s:%d", resource, startIdx/pageSize+1)
+
+	m.mu.Lock()
+	status, ok := m.failPages[key]
+	delete(m.failPages, key)
+	m.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	w.Header().Set("Retry-After", "0")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"type": "error", "status": status, "message": "Simulated page failure",
+	})
+	return true
+}
+
+func (m *mockIncidentIO) scenario() *mockScenario {
+	return &mockScenario{m: m}
+}
+
+func (s *mockScenario) onPath(path string) *mockScenario {
+	s.path = path
+	return s
+}
+
+func (s *mockScenario) fail(statusCode int) *mockScenario {
+	s.rule.status = statusCode
+	return s
+}
+
+// after serves the next n calls normally before failing.
+func (s *mockScenario) after(calls int) *mockScenario {
+	s.rule.after = calls
+	return s
+}
+
+// recoverAfter stops failing once n calls have failed.
+func (s *mockScenario) recoverAfter(failures int) *mockScenario {
+	s.rule.failures = failures
+	return s
+}
+
+// latency delays the response headers, i.e. time to first byte.
+func (s *mockScenario) latency(d time.Duration) *mockScenario {
+	s.rule.latency = d
+	return s
+}
+
+// bodyLatency flushes the headers, then delays the body by d.
+func (s *mockScenario) bodyLatency(d time.Duration) *mockScenario {
+	s.rule.bodyLatency = d
+	return s
+}
+
+// apply installs the rule, replacing any existing rule for the same path.
+func (s *mockScenario) apply() {
+	rule := s.rule
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	s.m.pathRules[s.path] = &rule
+}
+
+func (m *mockIncidentIO) clearScenario(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.pathRules, path)
+}
+
+// applyPathRules advances every rule matching path and returns the first
+// failure status (0 if none) and the total header and body latency to inject.
+func (m *mockIncidentIO) applyPathRules(path string) (int, time.Duration, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var status int
+	var latency, bodyLatency time.Duration
+	for prefix, rule := range m.pathRules {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		s, d, bd := rule.next()
+		if status == 0 {
+			status = s
+		}
+		latency += d
+		bodyLatency += bd
+	}
+	return status, latency, bodyLatency
+}
+
+// delayedBodyWriter sends the response headers as soon as the body starts,
+// then waits delay before writing it.
+type delayedBodyWriter struct {
+	http.ResponseWriter
+	delay   time.Duration
+	started bool
+}
+
+func (w *delayedBodyWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		if f, ok := w.ResponseWriter.(http.Flusher); ok {
+			f.Flush()
+		}
+		time.Sleep(w.delay)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// failUserEndpointAfter lets the next n requests to /v2/users succeed, then
+// fails every subsequent one with statusCode until recoverUserEndpoint.
+func (m *mockIncidentIO) failUserEndpointAfter(n int, statusCode int) {
+	m.scenario().onPath("/v2/users").fail(statusCode).after(n).apply()
+}
+
+func (m *mockIncidentIO) recoverUserEndpoint() {
+	m.clearScenario("/v2/users")
+}
+
 func (m *mockIncidentIO) logRequest(method, path string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -122,6 +400,14 @@ func (m *mockIncidentIO) logRequest(method, path string) {
 	atomic.AddInt32(&m.requestCount, 1)
 }
 
+// lastAuthSeen returns the Authorization header the most recent request
+// arrived with, e.g. to check a redirected request did not carry the API key.
+func (m *mockIncidentIO) lastAuthSeen() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.lastAuth
+}
+
 func (m *mockIncidentIO) getRequestCount() int {
 	return int(atomic.LoadInt32(&m.requestCount))
 }
@@ -134,19 +420,71 @@ func (m *mockIncidentIO) getRequestLog() []string {
 	return log
 }
 
+func (m *mockIncidentIO) logRequestBody(r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requestBodies = append(m.requestBodies, mockRequestBody{Method: r.Method, Path: r.URL.Path, Body: body})
+}
+
+func (m *mockIncidentIO) getRequestBodies() []mockRequestBody {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	bodies := make([]mockRequestBody, len(m.requestBodies))
+	copy(bodies, m.requestBodies)
+	return bodies
+}
+
+// getMaxInFlight returns the most requests the mock has served at once.
+func (m *mockIncidentIO) getMaxInFlight() int {
+	return int(atomic.LoadInt32(&m.maxInFlight))
+}
+
+func (m *mockIncidentIO) trackInFlight() func() {
+	n := atomic.AddInt32(&m.inFlight, 1)
+	for {
+		max := atomic.LoadInt32(&m.maxInFlight)
+		if n <= max || atomic.CompareAndSwapInt32(&m.maxInFlight, max, n) {
+			break
+		}
+	}
+	return func() { atomic.AddInt32(&m.inFlight, -1) }
+}
+
 func (m *mockIncidentIO) resetRequestLog() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.requestLog = nil
+	m.requestBodies = nil
+	m.lastAuth = ""
 	atomic.StoreInt32(&m.requestCount, 0)
+	atomic.StoreInt32(&m.maxInFlight, 0)
 }
 
 func (m *mockIncidentIO) serve() *httptest.Server {
-	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	return httptest.NewServer(m.handler())
+}
+
+// serveTLS serves the mock over HTTPS with a self-signed certificate. Trust it
+// via srv.Client() or a pool built from srv.Certificate().
+func (m *mockIncidentIO) serveTLS() *httptest.Server {
+	return httptest.NewTLSServer(m.handler())
+}
+
+func (m *mockIncidentIO) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		m.logRequest(r.Method, r.URL.Path)
+		m.logRequestBody(r)
+		defer m.trackInFlight()()
 
 		// Auth check
 		auth := r.Header.Get("Authorization")
+		m.mu.Lock()
+		m.lastAuth = auth
+		m.mu.Unlock()
 		if auth != "Bearer "+m.apiKey {
 			w.WriteHeader(401)
 			json.NewEncoder(w).Encode(map[string]interface{}{
@@ -170,6 +508,21 @@ func (m *mockIncidentIO) serve() *httptest.Server {
 		}
 		m.mu.RUnlock()
 
+		status, latency, bodyLatency := m.applyPathRules(path)
+		if latency > 0 {
+			time.Sleep(latency)
+		}
+		if bodyLatency > 0 {
+			w = &delayedBodyWriter{ResponseWriter: w, delay: bodyLatency}
+		}
+		if status != 0 {
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"type": "error", "status": status, "message": "Simulated scenario failure",
+			})
+			return
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 
 		switch {
@@ -185,13 +538,17 @@ func (m *mockIncidentIO) serve() *httptest.Server {
 			m.handleListUsers(w, r)
 		case strings.HasPrefix(path, "/v2/users/"):
 			m.handleGetUser(w, r)
+		case path == "/v2/escalation_paths":
+			m.handleListEscalationPaths(w, r)
+		case strings.HasPrefix(path, "/v2/escalation_paths/"):
+			m.handleGetEscalationPath(w, r)
 		default:
 			w.WriteHeader(404)
 			json.NewEncoder(w).Encode(map[string]interface{}{
 				"type": "not_found", "status": 404, "message": "Unknown endpoint",
 			})
 		}
-	}))
+	})
 }
 
 func (m *mockIncidentIO) handleIdentity(w http.ResponseWriter, r *http.Request) {
@@ -203,6 +560,9 @@ func (m *mockIncidentIO) handleIdentity(w http.ResponseWriter, r *http.Request)
 }
 
 func (m *mockIncidentIO) handleListSchedules(w http.ResponseWriter, r *http.Request) {
+	if m.failedListPage(w, r, "schedules", 25) {
+		return
+	}
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -213,9 +573,24 @@ func (m *mockIncidentIO) handleListSchedules(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	// Build sorted list
+	// Build sorted list so index cursors are stable across calls
+	ids := make([]string, 0, len(m.schedules))
+	for id := range m.schedules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
 	var all []map[string]interface{}
-	for _, s := range m.schedules {
+	nameFilter := strings.ToLower(r.URL.Query().Get("name"))
+	includeArchived := r.URL.Query().Get("include_archived") == "true"
+	for _, id := range ids {
+		s := m.schedules[id]
+		if nameFilter != "" && !strings.Contains(strings.ToLower(s.Name), nameFilter) {
+			continue
+		}
+		if m.archived[id] && !includeArchived {
+			continue
+		}
 		all = append(all, map[string]interface{}{"id": s.ID, "name": s.Name, "timezone": s.Timezone})
 	}
 
@@ -240,8 +615,12 @@ func (m *mockIncidentIO) handleListSchedules(w http.ResponseWriter, r *http.Requ
 		afterCursor = strconv.Itoa(endIdx)
 	}
 
+	key := "schedules"
+	if m.schedulesKey != "" {
+		key = m.schedulesKey
+	}
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"schedules": page,
+		key: page,
 		"pagination_meta": map[string]interface{}{
 			"after": afterCursor, "page_size": pageSize, "total_record_count": len(all),
 		},
@@ -261,6 +640,14 @@ func (m *mockIncidentIO) handleGetSchedule(w http.ResponseWriter, r *http.Reques
 		})
 		return
 	}
+
+	// HTTP dates have second precision, so compare truncated times
+	modified := s.LastModified.Truncate(time.Second)
+	if ims, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !modified.After(ims) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Last-Modified", modified.Format(http.TimeFormat))
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"schedule": map[string]interface{}{"id": s.ID, "name": s.Name, "timezone": s.Timezone},
 	})
@@ -305,9 +692,25 @@ func (m *mockIncidentIO) handleListEntries(w http.ResponseWriter, r *http.Reques
 		})
 	}
 
+	total, afterCursor, pageSize := len(entries), "", 250
+	if m.entryPageSize > 0 {
+		pageSize = m.entryPageSize
+		startIdx := 0
+		if v, _ := strconv.Atoi(r.URL.Query().Get("after")); v > 0 && v < len(entries) {
+			startIdx = v
+		}
+		endIdx := startIdx + m.entryPageSize
+		if endIdx < len(entries) {
+			afterCursor = strconv.Itoa(endIdx)
+		} else {
+			endIdx = len(entries)
+		}
+		entries = entries[startIdx:endIdx]
+	}
+
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"schedule_entries": entries,
-		"pagination_meta":  map[string]interface{}{"after": "", "page_size": 250, "total_record_count": len(entries)},
+		"pagination_meta":  map[string]interface{}{"after": afterCursor, "page_size": pageSize, "total_record_count": total},
 	})
 }
 
@@ -325,24 +728,119 @@ func (m *mockIncidentIO) handleGetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"user": map[string]interface{}{"id": u.ID, "name": u.Name, "email": u.Email, "role": u.Role},
+		"user": map[string]interface{}{"id": u.ID, "name": u.Name, "email": u.Email, "role": u.Role, "deactivated": u.Deactivated},
 	})
 }
 
 func (m *mockIncidentIO) handleListUsers(w http.ResponseWriter, r *http.Request) {
+	if m.failedListPage(w, r, "users", 250) {
+		return
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	pageSize := 250
+	if ps := r.URL.Query().Get("page_size"); ps != "" {
+		if v, _ := strconv.Atoi(ps); v > 0 {
+			pageSize = v
+		}
+	}
+
+	// Build sorted list so index cursors are stable across calls
+	ids := make([]string, 0, len(m.users))
+	for id := range m.users {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	all := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		u := m.users[id]
+		all = append(all, map[string]interface{}{"id": u.ID, "name": u.Name, "email": u.Email, "role": u.Role, "deactivated": u.Deactivated})
+	}
+
+	startIdx := 0
+	if after := r.URL.Query().Get("after"); after != "" {
+		if v, _ := strconv.Atoi(after); v > 0 {
+			startIdx = v
+		}
+	}
+	if startIdx > len(all) {
+		startIdx = len(all)
+	}
+	endIdx := startIdx + pageSize
+	if endIdx > len(all) {
+		endIdx = len(all)
+	}
+
+	afterCursor := ""
+	if endIdx < len(all) {
+		afterCursor = strconv.Itoa(endIdx)
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"users": all[startIdx:endIdx],
+		"pagination_meta": map[string]interface{}{
+			"after": afterCursor, "page_size": pageSize, "total_record_count": len(all),
+		},
+	})
+}
+
+func (m *mockIncidentIO) handleListEscalationPaths(w http.ResponseWriter, r *http.Request) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	var users []map[string]interface{}
-	for _, u := range m.users {
-		users = append(users, map[string]interface{}{"id": u.ID, "name": u.Name, "email": u.Email, "role": u.Role})
+	ids := make([]string, 0, len(m.escalations))
+	for id := range m.escalations {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	paths := make([]mockEscalationPath, 0, len(ids))
+	for _, id := range ids {
+		paths = append(paths, m.escalations[id])
 	}
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"users":           users,
-		"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": len(users)},
+		"escalation_paths": paths,
+		"pagination_meta":  map[string]interface{}{"after": "", "page_size": 250, "total_record_count": len(paths)},
 	})
 }
 
+func (m *mockIncidentIO) handleGetEscalationPath(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/v2/escalation_paths/")
+	p, ok := m.getEscalationPath(id)
+	if !ok {
+		w.WriteHeader(404)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"type": "not_found", "status": 404, "message": fmt.Sprintf("Escalation path %s not found", id),
+		})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"escalation_path": p})
+}
+
+// rawGet issues an authenticated GET straight to the mock, bypassing the SDK,
+// for mock features the SDK cannot reach yet: endpoints, query parameters,
+// headers and payload fields it has no API for (see DEFERRED_SDK_REQUESTS.md).
+// It authenticates as "test-key" and adds header, which may be nil. The caller
+// closes the response body.
+func rawGet(t *testing.T, srv *httptest.Server, path string, header http.Header) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest("GET", srv.URL+path, nil)
+	if err != nil {
+		t.Fatalf("FAIL: Build raw GET %s: %v", path, err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Authorization", "Bearer test-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("FAIL: Raw GET %s: %v", path, err)
+	}
+	return resp
+}
+
 // ============================================================================
 // Helper: simulates the integration layer's sync flow using SDK only
 // ============================================================================
@@ -352,12 +850,115 @@ type syncResult struct {
 	ScheduleName string
 	OnCallUsers  []resolvedUser
 	Error        error
+	Missing      bool // schedule was deleted; only set with syncOptions.TreatNotFoundAsMissing
+	Partial      bool // entries were truncated or user resolution stopped early; see syncOptions.MaxConsecutiveUserFailures
+	StartedAt    time.Time
+	Duration     time.Duration
 }
 
 type resolvedUser struct {
-	UserID string
-	Name   string
-	Email  string
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+// Reasons reported in syncWarning.Reason.
+const (
+	syncWarningMissingEmail     = "missing_email"
+	syncWarningUserUnresolved   = "user_unresolved"
+	syncWarningEntriesTruncated = "entries_truncated"
+)
+
+// syncWarning is a non-fatal data-quality problem found during sync.
+type syncWarning struct {
+	ScheduleID string
+	UserID     string
+	Reason     string
+}
+
+// syncOptions tunes simulateFullSyncWithOptions. The zero value matches
+// simulateFullSync.
+type syncOptions struct {
+	// OnWarning, if set, is called for each warning as it is found, so
+	// operators get visibility without parsing results.
+	OnWarning func(syncWarning)
+
+	// TreatNotFoundAsMissing reports deleted schedules (absent from the list
+	// or 404 on entries) as Missing with a nil Error, so callers can tell
+	// deletions apart from real failures.
+	TreatNotFoundAsMissing bool
+
+	// ScheduleFilter, if set, is applied to each tracked schedule after
+	// listing. Schedules it rejects are left out of the results entirely.
+	ScheduleFilter func(incidentio.Schedule) bool
+
+	// MaxConsecutiveUserFailures, if > 0, stops resolving a schedule's users
+	// after that many GetUser failures in a row (e.g. during a mass user
+	// deletion) and marks the result Partial.
+	MaxConsecutiveUserFailures int
+
+	// MaxConcurrentUserLookups, if > 0, bounds GetUser calls in flight across
+	// all schedules of a simulateFullSyncConcurrentWithOptions run,
+	// independently of how many schedules sync at once.
+	MaxConcurrentUserLookups int
+
+	// userLookups is the semaphore enforcing MaxConcurrentUserLookups, shared
+	// by every syncSchedule of one run.
+	userLookups chan struct{}
+}
+
+func (o syncOptions) warn(scheduleID, userID, reason string) {
+	if o.OnWarning != nil {
+		o.OnWarning(syncWarning{ScheduleID: scheduleID, UserID: userID, Reason: reason})
+	}
+}
+
+// syncError aggregates a sync's failures. Cause is set when the sync failed as
+// a whole (listing schedules) and is what Unwrap returns; per-schedule
+// failures are keyed by schedule ID.
+type syncError struct {
+	Cause          error
+	ScheduleErrors map[string]error
+}
+
+func (e *syncError) Error() string {
+	if e.Cause != nil {
+		return e.Cause.Error()
+	}
+	ids := make([]string, 0, len(e.ScheduleErrors))
+	for id := range e.ScheduleErrors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, id+": "+e.ScheduleErrors[id].Error())
+	}
+	return fmt.Sprintf("sync failed for %d schedule(s): %s", len(ids), strings.Join(parts, "; "))
+}
+
+func (e *syncError) Unwrap() error {
+	return e.Cause
+}
+
+// Errors returns the per-schedule failures, keyed by schedule ID.
+func (e *syncError) Errors() map[string]error {
+	return e.ScheduleErrors
+}
+
+// syncErrors collects the per-schedule failures in results into a *syncError,
+// or returns nil if every schedule synced.
+func syncErrors(results []syncResult) error {
+	errs := make(map[string]error)
+	for _, r := range results {
+		if r.Error != nil {
+			errs[r.ScheduleID] = r.Error
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return &syncError{ScheduleErrors: errs}
 }
 
 // simulateFullSync mimics what pkg/incidentio/sync.go FullSync does:
@@ -366,10 +967,15 @@ type resolvedUser struct {
 // 3. Resolve each user by ID
 // 4. Return the results (what would become group memberships)
 func simulateFullSync(ctx context.Context, client *incidentio.Client, trackedScheduleIDs []string) ([]syncResult, error) {
+	return simulateFullSyncWithOptions(ctx, client, trackedScheduleIDs, syncOptions{})
+}
+
+// simulateFullSyncWithOptions is simulateFullSync with tunable behavior.
+func simulateFullSyncWithOptions(ctx context.Context, client *incidentio.Client, trackedScheduleIDs []string, opts syncOptions) ([]syncResult, error) {
 	// Step 1: Verify schedules still exist
 	allSchedules, err := listAllSchedules(ctx, client)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list schedules: %w", err)
+		return nil, &syncError{Cause: fmt.Errorf("failed to list schedules: %w", err)}
 	}
 
 	scheduleMap := make(map[string]incidentio.Schedule)
@@ -380,912 +986,4278 @@ func simulateFullSync(ctx context.Context, client *incidentio.Client, trackedSch
 	// Step 2: For each tracked schedule, get on-call users
 	var results []syncResult
 	for _, schedID := range trackedScheduleIDs {
-		sched, exists := scheduleMap[schedID]
-		if !exists {
-			results = append(results, syncResult{
-				ScheduleID: schedID,
-				Error:      fmt.Errorf("schedule %s no longer exists", schedID),
-			})
+		if sched, ok := scheduleMap[schedID]; ok && opts.ScheduleFilter != nil && !opts.ScheduleFilter(sched) {
 			continue
 		}
+		results = append(results, syncSchedule(ctx, client, scheduleMap, schedID, opts))
+	}
 
-		// Get on-call entries
-		now := time.Now().UTC()
-		entryResp, err := client.ListScheduleEntriesWithContext(ctx, incidentio.ListScheduleEntriesOptions{
-			ScheduleID:       schedID,
-			EntryWindowStart: now.Format(time.RFC3339),
-			EntryWindowEnd:   now.Add(time.Minute).Format(time.RFC3339),
-		})
-		if err != nil {
-			results = append(results, syncResult{
-				ScheduleID:   schedID,
-				ScheduleName: sched.Name,
-				Error:        fmt.Errorf("failed to get entries: %w", err),
-			})
-			continue
-		}
+	return results, nil
+}
 
-		// Step 3: Resolve users
-		seen := make(map[string]bool)
-		var users []resolvedUser
-		for _, entry := range entryResp.ScheduleEntries {
-			if entry.User.ID == "" || seen[entry.User.ID] {
-				continue
-			}
-			seen[entry.User.ID] = true
+// defaultMaxConcurrentSchedules bounds simulateFullSyncConcurrent when the
+// caller passes maxSchedules < 1.
+const defaultMaxConcurrentSchedules = 10
 
-			user, err := client.GetUserWithContext(ctx, entry.User.ID, incidentio.GetUserOptions{})
-			if err != nil {
-				continue // skip unresolvable users
-			}
-			users = append(users, resolvedUser{
-				UserID: user.ID,
-				Name:   user.Name,
-				Email:  user.Email,
-			})
-		}
+// simulateFullSyncConcurrent is simulateFullSync with tracked schedules synced
+// in parallel, at most maxSchedules at a time, so a large org doesn't spawn a
+// goroutine per schedule. Results keep the order of trackedScheduleIDs.
+func simulateFullSyncConcurrent(ctx context.Context, client *incidentio.Client, trackedScheduleIDs []string, maxSchedules int) ([]syncResult, error) {
+	return simulateFullSyncConcurrentWithOptions(ctx, client, trackedScheduleIDs, maxSchedules, syncOptions{})
+}
 
-		results = append(results, syncResult{
-			ScheduleID:   schedID,
+// simulateFullSyncConcurrentWithOptions is simulateFullSyncConcurrent with
+// tunable behavior; see syncOptions.MaxConcurrentUserLookups.
+func simulateFullSyncConcurrentWithOptions(ctx context.Context, client *incidentio.Client, trackedScheduleIDs []string, maxSchedules int, opts syncOptions) ([]syncResult, error) {
+	allSchedules, err := listAllSchedules(ctx, client)
+	if err != nil {
+		return nil, &syncError{Cause: fmt.Errorf("failed to list schedules: %w", err)}
+	}
+
+	scheduleMap := make(map[string]incidentio.Schedule)
+	for _, s := range allSchedules {
+		scheduleMap[s.ID] = s
+	}
+	if opts.ScheduleFilter != nil {
+		var kept []string
+		for _, schedID := range trackedScheduleIDs {
+			if sched, ok := scheduleMap[schedID]; !ok || opts.ScheduleFilter(sched) {
+				kept = append(kept, schedID)
+			}
+		}
+		trackedScheduleIDs = kept
+	}
+
+	if maxSchedules < 1 {
+		maxSchedules = defaultMaxConcurrentSchedules
+	}
+	if opts.MaxConcurrentUserLookups > 0 {
+		opts.userLookups = make(chan struct{}, opts.MaxConcurrentUserLookups)
+	}
+	results := make([]syncResult, len(trackedScheduleIDs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxSchedules)
+	for i, schedID := range trackedScheduleIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, schedID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = syncSchedule(ctx, client, scheduleMap, schedID, opts)
+		}(i, schedID)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// syncSchedule gets the on-call entries for one tracked schedule and resolves
+// each user by ID. scheduleMap is the schedule list fetched at the start of
+// the sync; schedules missing from it are reported as deleted.
+func syncSchedule(ctx context.Context, client *incidentio.Client, scheduleMap map[string]incidentio.Schedule, schedID string, opts syncOptions) (result syncResult) {
+	// Timestamp every outcome, including errors, for audit trails
+	started := time.Now()
+	defer func() {
+		result.StartedAt = started.UTC()
+		result.Duration = time.Since(started)
+	}()
+
+	sched, exists := scheduleMap[schedID]
+	if !exists {
+		if opts.TreatNotFoundAsMissing {
+			return syncResult{ScheduleID: schedID, Missing: true}
+		}
+		return syncResult{
+			ScheduleID: schedID,
+			Error:      fmt.Errorf("schedule %s no longer exists", schedID),
+		}
+	}
+
+	// Get on-call entries
+	now := time.Now().UTC()
+	entryResp, err := client.ListScheduleEntriesWithContext(ctx, incidentio.ListScheduleEntriesOptions{
+		ScheduleID:       schedID,
+		EntryWindowStart: now.Format(time.RFC3339),
+		EntryWindowEnd:   now.Add(time.Minute).Format(time.RFC3339),
+	})
+	if err != nil {
+		var apiErr *incidentio.APIError
+		if opts.TreatNotFoundAsMissing && errors.As(err, &apiErr) && apiErr.IsNotFound() {
+			return syncResult{ScheduleID: schedID, ScheduleName: sched.Name, Missing: true}
+		}
+		return syncResult{
+			ScheduleID:   schedID,
 			ScheduleName: sched.Name,
-			OnCallUsers:  users,
+			Error:        fmt.Errorf("failed to get entries: %w", err),
+		}
+	}
+
+	// The SDK can't request further entry pages yet (see
+	// DEFERRED_SDK_REQUESTS.md), so flag the result rather than silently
+	// dropping users on later pages
+	partial := false
+	if entryResp.PaginationMeta.After != "" {
+		opts.warn(schedID, "", syncWarningEntriesTruncated)
+		partial = true
+	}
+
+	// Step 3: Resolve users
+	seen := make(map[string]bool)
+	var users []resolvedUser
+	consecutiveFailures := 0
+	for _, entry := range entryResp.ScheduleEntries {
+		// Stop resolving once the sync is cancelled instead of issuing
+		// lookups that can only fail
+		if err := ctx.Err(); err != nil {
+			return syncResult{
+				ScheduleID:   schedID,
+				ScheduleName: sched.Name,
+				OnCallUsers:  users,
+				Error:        fmt.Errorf("user resolution aborted: %w", err),
+			}
+		}
+		if entry.User.ID == "" || seen[entry.User.ID] {
+			continue
+		}
+		seen[entry.User.ID] = true
+
+		if opts.userLookups != nil {
+			// Waiting for a lookup slot must not outlive the sync
+			select {
+			case opts.userLookups <- struct{}{}:
+			case <-ctx.Done():
+				return syncResult{
+					ScheduleID:   schedID,
+					ScheduleName: sched.Name,
+					OnCallUsers:  users,
+					Error:        fmt.Errorf("user resolution aborted: %w", ctx.Err()),
+				}
+			}
+		}
+		user, err := client.GetUserWithContext(ctx, entry.User.ID, incidentio.GetUserOptions{})
+		if opts.userLookups != nil {
+			<-opts.userLookups
+		}
+		if err != nil {
+			opts.warn(schedID, entry.User.ID, syncWarningUserUnresolved)
+			consecutiveFailures++
+			if opts.MaxConsecutiveUserFailures > 0 && consecutiveFailures >= opts.MaxConsecutiveUserFailures {
+				partial = true
+				break
+			}
+			continue // skip unresolvable users
+		}
+		consecutiveFailures = 0
+		if user.Email == "" {
+			opts.warn(schedID, user.ID, syncWarningMissingEmail)
+		}
+		users = append(users, resolvedUser{
+			UserID: user.ID,
+			Name:   user.Name,
+			Email:  user.Email,
 		})
 	}
+	// Stable order so repeated syncs with the same membership are identical
+	sort.Slice(users, func(i, j int) bool { return users[i].UserID < users[j].UserID })
+
+	return syncResult{
+		ScheduleID:   schedID,
+		ScheduleName: sched.Name,
+		OnCallUsers:  users,
+		Partial:      partial,
+	}
+}
 
-	return results, nil
+// buildGroupMemberships maps each successfully synced schedule to the sorted
+// member list an identity sync would apply to its group. Members are keyed by
+// email, falling back to user ID for users without one. Errored and missing
+// schedules are skipped so their groups keep their previous members.
+func buildGroupMemberships(results []syncResult) map[string][]string {
+	groups := make(map[string][]string)
+	for _, r := range results {
+		if r.Error != nil || r.Missing {
+			continue
+		}
+		members := make([]string, 0, len(r.OnCallUsers))
+		for _, u := range r.OnCallUsers {
+			if u.Email != "" {
+				members = append(members, u.Email)
+			} else {
+				members = append(members, u.UserID)
+			}
+		}
+		sort.Strings(members)
+		groups[r.ScheduleID] = members
+	}
+	return groups
+}
+
+// groupResultsByUser is the inverse of buildGroupMemberships: it maps each
+// on-call user ID to the sorted IDs of the schedules they are on. Errored and
+// missing schedules are skipped.
+func groupResultsByUser(results []syncResult) map[string][]string {
+	byUser := make(map[string][]string)
+	for _, r := range results {
+		if r.Error != nil || r.Missing {
+			continue
+		}
+		for _, u := range r.OnCallUsers {
+			byUser[u.UserID] = append(byUser[u.UserID], r.ScheduleID)
+		}
+	}
+	for _, ids := range byUser {
+		sort.Strings(ids)
+	}
+	return byUser
+}
+
+// onCallSnapshotLine is one NDJSON record written by writeOnCallSnapshot.
+type onCallSnapshotLine struct {
+	Timestamp    time.Time      `json:"timestamp"`
+	ScheduleID   string         `json:"schedule_id"`
+	ScheduleName string         `json:"schedule_name"`
+	Users        []resolvedUser `json:"users"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// writeOnCallSnapshot emits sync results as newline-delimited JSON, one object
+// per schedule, for piping into data pipelines. All lines share one timestamp.
+func writeOnCallSnapshot(w io.Writer, results []syncResult) error {
+	now := time.Now().UTC()
+	enc := json.NewEncoder(w)
+	for _, r := range results {
+		line := onCallSnapshotLine{
+			Timestamp:    now,
+			ScheduleID:   r.ScheduleID,
+			ScheduleName: r.ScheduleName,
+			Users:        r.OnCallUsers,
+		}
+		if line.Users == nil {
+			line.Users = []resolvedUser{}
+		}
+		if r.Error != nil {
+			line.Error = r.Error.Error()
+		}
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("write snapshot for %s: %w", r.ScheduleID, err)
+		}
+	}
+	return nil
+}
+
+// resolveOnCall returns the users currently on call for a schedule, resolved
+// by ID. Entries with empty or duplicate user IDs and users that can't be
+// resolved are skipped, matching simulateFullSync.
+func resolveOnCall(ctx context.Context, client *incidentio.Client, scheduleID string) ([]incidentio.User, error) {
+	now := time.Now().UTC()
+	entryResp, err := client.ListScheduleEntriesWithContext(ctx, incidentio.ListScheduleEntriesOptions{
+		ScheduleID:       scheduleID,
+		EntryWindowStart: now.Format(time.RFC3339),
+		EntryWindowEnd:   now.Add(time.Minute).Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entries: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var users []incidentio.User
+	for _, entry := range entryResp.ScheduleEntries {
+		if entry.User.ID == "" || seen[entry.User.ID] {
+			continue
+		}
+		seen[entry.User.ID] = true
+
+		user, err := client.GetUserWithContext(ctx, entry.User.ID, incidentio.GetUserOptions{})
+		if err != nil {
+			continue
+		}
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+// listOnCallUserIDs returns the IDs of users on call for a schedule at the
+// given instant, deduplicated in first-seen order with empty IDs dropped, for
+// callers that don't need full user records.
+func listOnCallUserIDs(ctx context.Context, client *incidentio.Client, scheduleID string, at time.Time) ([]string, error) {
+	entryResp, err := client.ListScheduleEntriesWithContext(ctx, incidentio.ListScheduleEntriesOptions{
+		ScheduleID:       scheduleID,
+		EntryWindowStart: at.UTC().Format(time.RFC3339),
+		EntryWindowEnd:   at.UTC().Add(time.Minute).Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entries: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	ids := []string{}
+	for _, entry := range entryResp.ScheduleEntries {
+		if entry.User.ID == "" || seen[entry.User.ID] {
+			continue
+		}
+		seen[entry.User.ID] = true
+		ids = append(ids, entry.User.ID)
+	}
+	return ids, nil
+}
+
+// nextHandoffLocal returns when the on-call for a schedule next changes after
+// at, expressed in the schedule's own timezone, along with that zone's name.
+// A schedule without a timezone is reported in UTC.
+func nextHandoffLocal(ctx context.Context, client *incidentio.Client, scheduleID string, at time.Time) (time.Time, string, error) {
+	sched, err := client.GetScheduleWithContext(ctx, scheduleID, incidentio.GetScheduleOptions{})
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("failed to get schedule: %w", err)
+	}
+	loc, err := time.LoadLocation(sched.Timezone)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("schedule %s timezone: %w", scheduleID, err)
+	}
+
+	entryResp, err := client.ListScheduleEntriesWithContext(ctx, incidentio.ListScheduleEntriesOptions{
+		ScheduleID:       scheduleID,
+		EntryWindowStart: at.UTC().Format(time.RFC3339),
+		EntryWindowEnd:   at.UTC().Add(time.Minute).Format(time.RFC3339),
+	})
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("failed to get entries: %w", err)
+	}
+	handoff, ok := nextHandoff(entryResp.ScheduleEntries, at)
+	if !ok {
+		return time.Time{}, "", fmt.Errorf("schedule %s has nobody on call", scheduleID)
+	}
+	return handoff.In(loc), loc.String(), nil
+}
+
+// enrichEntriesWithUsers returns a copy of entries with missing user emails
+// filled in from GetUser, since the entries payload sometimes omits them. Each
+// user is looked up at most once; entries that already carry an email are not
+// looked up at all.
+func enrichEntriesWithUsers(ctx context.Context, client *incidentio.Client, entries []incidentio.ScheduleEntry) ([]incidentio.ScheduleEntry, error) {
+	enriched := make([]incidentio.ScheduleEntry, len(entries))
+	emails := make(map[string]string)
+	for i, e := range entries {
+		if e.User.Email == "" && e.User.ID != "" {
+			email, ok := emails[e.User.ID]
+			if !ok {
+				user, err := client.GetUserWithContext(ctx, e.User.ID, incidentio.GetUserOptions{})
+				if err != nil {
+					return nil, fmt.Errorf("enrich entry %s: %w", e.EntryID, err)
+				}
+				email = user.Email
+				emails[e.User.ID] = email
+			}
+			e.User.Email = email
+		}
+		enriched[i] = e
+	}
+	return enriched, nil
+}
+
+// getAllOnCall resolves current on-call users for every schedule in one call,
+// for a global on-call dashboard. Schedules are processed with at most
+// concurrency workers and failures are isolated: each schedule lands in
+// exactly one of the two returned maps.
+func getAllOnCall(ctx context.Context, client *incidentio.Client, scheduleIDs []string, concurrency int) (map[string][]incidentio.User, map[string]error) {
+	onCall := make(map[string][]incidentio.User)
+	errs := make(map[string]error)
+
+	allSchedules, err := listAllSchedules(ctx, client)
+	if err != nil {
+		for _, id := range scheduleIDs {
+			errs[id] = fmt.Errorf("failed to list schedules: %w", err)
+		}
+		return onCall, errs
+	}
+	exists := make(map[string]bool)
+	for _, s := range allSchedules {
+		exists[s.ID] = true
+	}
+
+	// Record deleted schedules before any worker starts writing to errs
+	var listed []string
+	for _, id := range scheduleIDs {
+		if !exists[id] {
+			errs[id] = fmt.Errorf("schedule %s no longer exists", id)
+			continue
+		}
+		listed = append(listed, id)
+	}
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for _, id := range listed {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			users, err := resolveOnCall(ctx, client, id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[id] = err
+				return
+			}
+			onCall[id] = users
+		}(id)
+	}
+	wg.Wait()
+	return onCall, errs
+}
+
+// snapshotOnCall resolves current on-call users for several schedules against
+// one shared user index, so a user on many schedules costs nothing extra: the
+// whole snapshot is one paginated ListUsers plus one entries call per schedule,
+// run at most defaultMaxConcurrentSchedules at a time. Entries for users
+// missing from the index are skipped. Any schedule failure fails the snapshot.
+func snapshotOnCall(ctx context.Context, client *incidentio.Client, scheduleIDs []string) (map[string][]incidentio.User, error) {
+	index, err := buildUserIndex(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	onCall := make(map[string][]incidentio.User)
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, defaultMaxConcurrentSchedules)
+	for _, id := range scheduleIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			userIDs, err := listOnCallUserIDs(ctx, client, id, time.Now())
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("schedule %s: %w", id, err)
+				}
+				return
+			}
+			users := []incidentio.User{}
+			for _, uid := range userIDs {
+				if u, ok := index[uid]; ok {
+					users = append(users, u)
+				}
+			}
+			onCall[id] = users
+		}(id)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return onCall, nil
+}
+
+// escalationOnCall resolves who each level of an escalation path would page
+// right now, level 0 being the primary. levels holds each step's targets, which
+// are schedule or user IDs; a schedule resolves to its current on-call users.
+// Users are deduplicated within a level and kept in target order. The SDK
+// cannot fetch escalation paths yet, so callers pass the steps in.
+func escalationOnCall(ctx context.Context, client *incidentio.Client, levels [][]string) ([][]incidentio.User, error) {
+	schedules, err := listAllSchedules(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+	isSchedule := make(map[string]bool, len(schedules))
+	for _, s := range schedules {
+		isSchedule[s.ID] = true
+	}
+	index, err := buildUserIndex(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([][]incidentio.User, len(levels))
+	for level, targets := range levels {
+		seen := make(map[string]bool)
+		users := []incidentio.User{}
+		for _, target := range targets {
+			userIDs := []string{target}
+			if isSchedule[target] {
+				if userIDs, err = listOnCallUserIDs(ctx, client, target, time.Now()); err != nil {
+					return nil, fmt.Errorf("level %d schedule %s: %w", level, target, err)
+				}
+			} else if _, ok := index[target]; !ok {
+				return nil, fmt.Errorf("level %d: target %s is neither a schedule nor a user", level, target)
+			}
+			for _, id := range userIDs {
+				u, ok := index[id]
+				if !ok || seen[id] {
+					continue
+				}
+				seen[id] = true
+				users = append(users, u)
+			}
+		}
+		out[level] = users
+	}
+	return out, nil
+}
+
+// maxListPages bounds the list helpers' pagination loops.
+const maxListPages = 100
+
+// errMaxPagesExceeded is returned by the list helpers when they stop at
+// maxListPages with a cursor still remaining, rather than returning a
+// silently truncated list.
+var errMaxPagesExceeded = errors.New("max pages exceeded")
+
+// errCountMismatch is returned by listAllSchedulesWithOptions with VerifyCount
+// set when the schedules returned disagree with total_record_count.
+var errCountMismatch = errors.New("record count mismatch")
+
+// listAllOptions configures listAllSchedulesWithOptions.
+type listAllOptions struct {
+	// VerifyCount checks the number of schedules returned against the
+	// total_record_count reported on the last page.
+	VerifyCount bool
 }
 
 // listAllSchedules handles pagination to get all schedules
 func listAllSchedules(ctx context.Context, client *incidentio.Client) ([]incidentio.Schedule, error) {
+	return listAllSchedulesWithOptions(ctx, client, listAllOptions{})
+}
+
+// listAllSchedulesWithOptions is listAllSchedules with optional checks.
+func listAllSchedulesWithOptions(ctx context.Context, client *incidentio.Client, listOpts listAllOptions) ([]incidentio.Schedule, error) {
 	var all []incidentio.Schedule
 	opts := incidentio.ListSchedulesOptions{PageSize: 250}
-	for page := 0; page < 100; page++ {
+	for page := 0; page < maxListPages; page++ {
 		resp, err := client.ListSchedulesWithContext(ctx, opts)
 		if err != nil {
 			return nil, err
 		}
 		all = append(all, resp.Schedules...)
 		if resp.PaginationMeta.After == "" {
-			break
+			// Converted explicitly: the SDK's integer type for this field is not
+			// pinned (see TestEDGE_LargeTotalRecordCountPrecision)
+			if total := int64(resp.PaginationMeta.TotalRecordCount); listOpts.VerifyCount && total != int64(len(all)) {
+				return nil, fmt.Errorf("list schedules: %w: got %d, API reported %d", errCountMismatch, len(all), total)
+			}
+			return all, nil
 		}
 		opts.After = resp.PaginationMeta.After
 	}
+	return nil, fmt.Errorf("list schedules: %w after %d pages", errMaxPagesExceeded, maxListPages)
+}
+
+// listAllUsers walks the users cursor starting from opts (PageSize defaults
+// to 250). It stops with an error if the context is cancelled between pages or
+// the API hands back a cursor it already returned.
+func listAllUsers(ctx context.Context, client *incidentio.Client, opts incidentio.ListUsersOptions) ([]incidentio.User, error) {
+	if opts.PageSize == 0 {
+		opts.PageSize = 250
+	}
+	var all []incidentio.User
+	seenCursors := make(map[string]bool)
+	for page := 0; page < maxListPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		resp, err := client.ListUsersWithContext(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Users...)
+		after := resp.PaginationMeta.After
+		if after == "" {
+			return all, nil
+		}
+		if seenCursors[after] {
+			return nil, fmt.Errorf("pagination loop: cursor %q repeated", after)
+		}
+		seenCursors[after] = true
+		opts.After = after
+	}
+	return nil, fmt.Errorf("list users: %w after %d pages", errMaxPagesExceeded, maxListPages)
+}
+
+// buildUserIndex maps user ID to user from a paginated ListUsers, so bulk
+// lookups cost one request per page instead of one GetUser per entry.
+func buildUserIndex(ctx context.Context, client *incidentio.Client) (map[string]incidentio.User, error) {
+	users, err := listAllUsers(ctx, client, incidentio.ListUsersOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	index := make(map[string]incidentio.User, len(users))
+	for _, u := range users {
+		index[u.ID] = u
+	}
+	return index, nil
+}
+
+// filterEntriesByRole keeps entries whose user has the given role, e.g. to
+// build responder-only groups. Users are resolved through buildUserIndex;
+// entries for users missing from the index are dropped.
+func filterEntriesByRole(ctx context.Context, client *incidentio.Client, entries []incidentio.ScheduleEntry, role string) ([]incidentio.ScheduleEntry, error) {
+	index, err := buildUserIndex(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	var filtered []incidentio.ScheduleEntry
+	for _, e := range entries {
+		if user, ok := index[e.User.ID]; ok && user.Role == role {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered, nil
+}
+
+// errDuplicateSchedule is returned by listAllSchedulesUnique when the API
+// repeats a schedule ID across pages.
+var errDuplicateSchedule = errors.New("duplicate schedule in list")
+
+// listAllSchedulesUnique is listAllSchedules for callers that key by schedule
+// ID and must not silently double-count: a repeated ID is an error rather than
+// a second copy in the result.
+func listAllSchedulesUnique(ctx context.Context, client *incidentio.Client) ([]incidentio.Schedule, error) {
+	all, err := listAllSchedules(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(all))
+	for _, s := range all {
+		if seen[s.ID] {
+			return nil, fmt.Errorf("%w: %s", errDuplicateSchedule, s.ID)
+		}
+		seen[s.ID] = true
+	}
 	return all, nil
 }
 
-// ============================================================================
-// FUNCTIONAL TESTS — Happy Paths
-// ============================================================================
+// incrementalListSchedules lists schedules starting from a cursor saved by a
+// previous call instead of from the first page. It returns the cursor the last
+// page was fetched with, so the next cycle re-reads that page and picks up
+// anything appended after it. The API has no "changed since" filter, so edits
+// and deletions before the cursor are only seen by a full list (sinceCursor "").
+func incrementalListSchedules(ctx context.Context, client *incidentio.Client, sinceCursor string, pageSize int) ([]incidentio.Schedule, string, error) {
+	var all []incidentio.Schedule
+	opts := incidentio.ListSchedulesOptions{PageSize: pageSize, After: sinceCursor}
+	for page := 0; page < maxListPages; page++ {
+		resp, err := client.ListSchedulesWithContext(ctx, opts)
+		if err != nil {
+			return nil, sinceCursor, err
+		}
+		all = append(all, resp.Schedules...)
+		if resp.PaginationMeta.After == "" {
+			return all, opts.After, nil
+		}
+		opts.After = resp.PaginationMeta.After
+	}
+	return nil, sinceCursor, fmt.Errorf("list schedules: %w after %d pages", errMaxPagesExceeded, maxListPages)
+}
 
-func TestFUNC_CreateIntegrationAndTestConnection(t *testing.T) {
-	mock := newMockIncidentIO("prod-api-key-abc123")
-	mock.addSchedule("sched-001", "Platform On-Call", "America/New_York")
-	srv := mock.serve()
-	defer srv.Close()
+// streamSchedules emits schedules page by page as they are fetched, for UIs
+// that render before the full list is in. Both channels are closed when
+// listing finishes; at most one error is sent, after which nothing more is
+// emitted. Callers must drain the schedule channel or cancel ctx.
+func streamSchedules(ctx context.Context, client *incidentio.Client, opts incidentio.ListSchedulesOptions) (<-chan incidentio.Schedule, <-chan error) {
+	out := make(chan incidentio.Schedule)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(out)
+		defer close(errc)
+		for page := 0; page < maxListPages; page++ {
+			resp, err := client.ListSchedulesWithContext(ctx, opts)
+			if err != nil {
+				errc <- err
+				return
+			}
+			for _, s := range resp.Schedules {
+				select {
+				case out <- s:
+				case <-ctx.Done():
+					errc <- ctx.Err()
+					return
+				}
+			}
+			if resp.PaginationMeta.After == "" {
+				return
+			}
+			opts.After = resp.PaginationMeta.After
+		}
+		errc <- fmt.Errorf("list schedules: %w after %d pages", errMaxPagesExceeded, maxListPages)
+	}()
+	return out, errc
+}
 
-	// Step 1: Test connection with correct API key
-	client := incidentio.NewClient("prod-api-key-abc123", incidentio.WithBaseURL(srv.URL))
-	schedules, err := listAllSchedules(context.Background(), client)
+// listSchedulesByName returns schedules whose name contains nameContains
+// (case-insensitive). The SDK has no server-side name filter yet, so this
+// lists everything and filters client-side.
+func listSchedulesByName(ctx context.Context, client *incidentio.Client, nameContains string) ([]incidentio.Schedule, error) {
+	all, err := listAllSchedules(ctx, client)
 	if err != nil {
-		t.Fatalf("FUNC-CREATE FAIL: Connection test failed: %v", err)
+		return nil, err
 	}
-	if len(schedules) != 1 {
-		t.Fatalf("FUNC-CREATE FAIL: Expected 1 schedule, got %d", len(schedules))
+	needle := strings.ToLower(nameContains)
+	var matched []incidentio.Schedule
+	for _, s := range all {
+		if strings.Contains(strings.ToLower(s.Name), needle) {
+			matched = append(matched, s)
+		}
 	}
+	return matched, nil
+}
 
-	// Step 2: Test connection with wrong API key
-	badClient := incidentio.NewClient("wrong-key", incidentio.WithBaseURL(srv.URL))
-	_, err = listAllSchedules(context.Background(), badClient)
-	if err == nil {
-		t.Fatal("FUNC-CREATE FAIL: Wrong API key should fail")
+// findSchedulesWithoutTimezone returns schedules with an empty timezone, which
+// the API can produce (see TestEDGE_NullFieldsInResponse) and which break
+// local-time handoff calculations.
+func findSchedulesWithoutTimezone(ctx context.Context, client *incidentio.Client) ([]incidentio.Schedule, error) {
+	all, err := listAllSchedules(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	var missing []incidentio.Schedule
+	for _, s := range all {
+		if s.Timezone == "" {
+			missing = append(missing, s)
+		}
+	}
+	return missing, nil
+}
+
+// ============================================================================
+// Helper: API key validation before the first call
+// ============================================================================
+
+// validateAPIKey catches paste errors locally instead of as a 401 from the
+// API: an empty key, surrounding whitespace, embedded whitespace or control
+// characters, or a pasted "Bearer " prefix.
+func validateAPIKey(key string) error {
+	switch {
+	case key == "":
+		return errors.New("API key is empty")
+	case strings.TrimSpace(key) != key:
+		return errors.New("API key has leading or trailing whitespace")
+	case strings.HasPrefix(strings.ToLower(key), "bearer "):
+		return errors.New(`API key includes the "Bearer " prefix`)
+	}
+	for _, r := range key {
+		if r <= ' ' || r == 0x7f {
+			return fmt.Errorf("API key contains invalid character %q", r)
+		}
+	}
+	return nil
+}
+
+// normalizeAPIKey trims surrounding whitespace (e.g. the newline a copied
+// secret often ends with) and validates what is left, so configuration
+// mistakes fail before the key reaches NewClient.
+func normalizeAPIKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+	if err := validateAPIKey(key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// ============================================================================
+// Helper: client-side transports plugged in via WithHTTPClient
+// ============================================================================
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// preRequestTransport calls hook on a clone of every outgoing request (each
+// retry attempt included) after the SDK has set its headers, e.g. to sign it
+// for an auth proxy. A hook error aborts the request. Removing the
+// Authorization header is treated as a hook error rather than sent silently.
+func preRequestTransport(next http.RoundTripper, hook func(*http.Request) error) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		req := r.Clone(r.Context())
+		if err := hook(req); err != nil {
+			return nil, fmt.Errorf("pre-request hook: %w", err)
+		}
+		if r.Header.Get("Authorization") != "" && req.Header.Get("Authorization") == "" {
+			return nil, errors.New("pre-request hook: removed the Authorization header")
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+type baseURLOverrideKey struct{}
+
+// withBaseURLOverride directs calls made with ctx to baseURL instead of the
+// client's default, when the client uses baseURLOverrideTransport.
+func withBaseURLOverride(ctx context.Context, baseURL string) context.Context {
+	return context.WithValue(ctx, baseURLOverrideKey{}, baseURL)
+}
+
+// baseURLOverrideTransport rewrites the scheme and host of requests whose
+// context carries a withBaseURLOverride value, e.g. to reach another region
+// for one call. The request path is kept as the SDK built it.
+func baseURLOverrideTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		override, ok := r.Context().Value(baseURLOverrideKey{}).(string)
+		if !ok {
+			return next.RoundTrip(r)
+		}
+		target, err := url.Parse(override)
+		if err != nil {
+			return nil, fmt.Errorf("base URL override: %w", err)
+		}
+		req := r.Clone(r.Context())
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+		req.Host = target.Host
+		return next.RoundTrip(req)
+	})
+}
+
+// requestLogEntry is one round trip reported to a withRequestLogger logger.
+// Status is 0 when Err is set.
+type requestLogEntry struct {
+	Method   string
+	Path     string
+	Query    string
+	Status   int
+	Duration time.Duration
+	Bucket   string // latencyBucket(Duration), for SLO histograms
+	Err      error
+}
+
+// Latency buckets reported in requestLogEntry.Bucket.
+const (
+	latencyUnder100ms = "<100ms"
+	latencyUnder500ms = "<500ms"
+	latencyUnder1s    = "<1s"
+	latencyOver1s     = ">=1s"
+)
+
+// latencyBucket classifies a round trip duration for latency SLOs.
+func latencyBucket(d time.Duration) string {
+	switch {
+	case d < 100*time.Millisecond:
+		return latencyUnder100ms
+	case d < 500*time.Millisecond:
+		return latencyUnder500ms
+	case d < time.Second:
+		return latencyUnder1s
+	default:
+		return latencyOver1s
+	}
+}
+
+type requestLoggerKey struct{}
+
+// withRequestLogger attaches a logger to calls made with ctx, for debugging a
+// single call, when the client uses requestLoggerTransport. Each attempt,
+// retries included, is logged once its response headers arrive.
+func withRequestLogger(ctx context.Context, logf func(requestLogEntry)) context.Context {
+	return context.WithValue(ctx, requestLoggerKey{}, logf)
+}
+
+// requestLoggerTransport reports round trips to the logger attached by
+// withRequestLogger, if any. Requests without one pass through untouched.
+func requestLoggerTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		logf, ok := r.Context().Value(requestLoggerKey{}).(func(requestLogEntry))
+		if !ok {
+			return next.RoundTrip(r)
+		}
+		start := time.Now()
+		resp, err := next.RoundTrip(r)
+		d := time.Since(start)
+		entry := requestLogEntry{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Duration: d, Bucket: latencyBucket(d), Err: err}
+		if err == nil {
+			entry.Status = resp.StatusCode
+		}
+		logf(entry)
+		return resp, err
+	})
+}
+
+// slowRequest is reported by slowRequestTransport for a call over its threshold.
+type slowRequest struct {
+	Endpoint string
+	Duration time.Duration
+}
+
+// slowRequestTransport wraps next and calls onSlow for every request whose
+// round trip (up to response headers) takes longer than threshold, so
+// operators can alert on slow calls without timing each SDK method.
+func slowRequestTransport(next http.RoundTripper, threshold time.Duration, onSlow func(slowRequest)) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(r)
+		if d := time.Since(start); d > threshold {
+			onSlow(slowRequest{Endpoint: r.Method + " " + r.URL.Path, Duration: d})
+		}
+		return resp, err
+	})
+}
+
+// defaultContextTimeoutTransport gives requests whose context has no deadline
+// one of d, so calls made with context.Background() cannot hang forever on a
+// stalled server. A caller's own deadline is left untouched.
+func defaultContextTimeoutTransport(next http.RoundTripper, d time.Duration) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if _, ok := r.Context().Deadline(); ok {
+			return next.RoundTrip(r)
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), d)
+		resp, err := next.RoundTrip(r.WithContext(ctx))
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		// The deadline must also cover reading the body
+		resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+		return resp, nil
+	})
+}
+
+// cancelOnCloseBody releases a request context once its response body is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// requireHTTPSTransport refuses to send requests over plain http, so a
+// mistyped base URL cannot leak the API key in cleartext. Loopback hosts are
+// exempt for local testing.
+func requireHTTPSTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Scheme != "https" && !isLoopbackHost(r.URL.Hostname()) {
+			return nil, fmt.Errorf("refusing non-https request to %s://%s", r.URL.Scheme, r.URL.Host)
+		}
+		return next.RoundTrip(r)
+	})
+}
+
+// isLoopbackHost reports whether host is localhost or a loopback IP.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// retryOnStatusTransport retries requests answered with one of codes, up to
+// attempts tries in total, on top of the SDK's own 429 handling. Only GETs
+// are retried, since those are all the SDK sends and they carry no body. The
+// last response is returned as-is, so a persistent failure still surfaces
+// with its real status.
+func retryOnStatusTransport(next http.RoundTripper, attempts int, codes ...int) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	retryable := make(map[int]bool, len(codes))
+	for _, c := range codes {
+		retryable[c] = true
+	}
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		for attempt := 1; ; attempt++ {
+			resp, err := next.RoundTrip(r)
+			if err != nil || r.Method != http.MethodGet || !retryable[resp.StatusCode] || attempt >= attempts {
+				return resp, err
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+			if err := r.Context().Err(); err != nil {
+				return nil, err
+			}
+		}
+	})
+}
+
+// transportStats counts requests seen by statsTransport. Each retry attempt
+// counts as a request of its own and also in Retries.
+type transportStats struct {
+	Requests        int
+	Retries         int
+	Status4xx       int
+	Status5xx       int
+	TransportErrors int
+}
+
+// statsTransport counts outgoing requests by outcome, standing in for a client
+// Stats() accessor until the SDK has one (see DEFERRED_SDK_REQUESTS.md). The
+// transport cannot see the SDK's retry loop, so a request counts as a retry
+// when the previous response for the same method and URL was a 429, the only
+// status the SDK retries.
+type statsTransport struct {
+	next    http.RoundTripper
+	mu      sync.Mutex
+	s       transportStats
+	last429 map[string]bool // method + URL -> latest response was a 429
+}
+
+func newStatsTransport(next http.RoundTripper) *statsTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &statsTransport{next: next, last429: make(map[string]bool)}
+}
+
+func (t *statsTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	key := r.Method + " " + r.URL.String()
+	resp, err := t.next.RoundTrip(r)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.s.Requests++
+	if t.last429[key] {
+		t.s.Retries++
+	}
+	t.last429[key] = err == nil && resp.StatusCode == http.StatusTooManyRequests
+	switch {
+	case err != nil:
+		t.s.TransportErrors++
+	case resp.StatusCode >= 500:
+		t.s.Status5xx++
+	case resp.StatusCode >= 400:
+		t.s.Status4xx++
+	}
+	return resp, err
+}
+
+// stats returns a consistent snapshot of the counters.
+func (t *statsTransport) stats() transportStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.s
+}
+
+// resetStats zeroes every counter at once, e.g. between sync cycles. It
+// returns the counters as they were, so each request lands in exactly one cycle.
+func (t *statsTransport) resetStats() transportStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	prev := t.s
+	t.s = transportStats{}
+	return prev
+}
+
+// ============================================================================
+// FUNCTIONAL TESTS — Happy Paths
+// ============================================================================
+
+func TestFUNC_CreateIntegrationAndTestConnection(t *testing.T) {
+	mock := newMockIncidentIO("prod-api-key-abc123")
+	mock.addSchedule("sched-001", "Platform On-Call", "America/New_York")
+	srv := mock.serve()
+	defer srv.Close()
+
+	// Step 1: Test connection with correct API key
+	client := incidentio.NewClient("prod-api-key-abc123", incidentio.WithBaseURL(srv.URL))
+	schedules, err := listAllSchedules(context.Background(), client)
+	if err != nil {
+		t.Fatalf("FUNC-CREATE FAIL: Connection test failed: %v", err)
+	}
+	if len(schedules) != 1 {
+		t.Fatalf("FUNC-CREATE FAIL: Expected 1 schedule, got %d", len(schedules))
+	}
+
+	// Step 2: Test connection with wrong API key
+	badClient := incidentio.NewClient("wrong-key", incidentio.WithBaseURL(srv.URL))
+	_, err = listAllSchedules(context.Background(), badClient)
+	if err == nil {
+		t.Fatal("FUNC-CREATE FAIL: Wrong API key should fail")
+	}
+
+	t.Log("FUNC-CREATE PASS: Integration created and connection tested successfully")
+}
+
+func TestFUNC_AddSchedulesSyncAndVerifyOnCall(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Platform On-Call", "America/New_York")
+	mock.addSchedule("sched-002", "Backend Primary", "America/Los_Angeles")
+	mock.addUser("user-alice", "Alice Chen", "alice@example.com", "responder")
+	mock.addUser("user-bob", "Bob Martinez", "bob@example.com", "responder")
+	mock.addUser("user-carol", "Carol Davis", "carol@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-alice", "user-bob"})
+	mock.setOnCall("sched-002", []string{"user-carol"})
+
+	srv := mock.serve()
+	defer srv.Close()
+
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Step 1: List available schedules
+	schedules, err := listAllSchedules(context.Background(), client)
+	if err != nil {
+		t.Fatalf("FUNC-SYNC FAIL: List schedules: %v", err)
+	}
+	if len(schedules) != 2 {
+		t.Fatalf("FUNC-SYNC FAIL: Expected 2 schedules, got %d", len(schedules))
+	}
+
+	// Step 2: "Add" both schedules (track them)
+	tracked := []string{"sched-001", "sched-002"}
+
+	// Step 3: Run sync
+	results, err := simulateFullSync(context.Background(), client, tracked)
+	if err != nil {
+		t.Fatalf("FUNC-SYNC FAIL: Sync failed: %v", err)
+	}
+
+	// Step 4: Verify results
+	if len(results) != 2 {
+		t.Fatalf("FUNC-SYNC FAIL: Expected 2 results, got %d", len(results))
+	}
+
+	for _, r := range results {
+		if r.Error != nil {
+			t.Fatalf("FUNC-SYNC FAIL: Schedule %s error: %v", r.ScheduleID, r.Error)
+		}
+	}
+
+	// Verify sched-001 has alice and bob
+	r1 := results[0]
+	if r1.ScheduleID == "sched-002" {
+		r1 = results[1]
+	}
+	if len(r1.OnCallUsers) != 2 {
+		t.Fatalf("FUNC-SYNC FAIL: sched-001 should have 2 on-call users, got %d", len(r1.OnCallUsers))
+	}
+
+	// Verify sched-002 has carol
+	r2 := results[1]
+	if r2.ScheduleID == "sched-001" {
+		r2 = results[0]
+	}
+	if len(r2.OnCallUsers) != 1 {
+		t.Fatalf("FUNC-SYNC FAIL: sched-002 should have 1 on-call user, got %d", len(r2.OnCallUsers))
+	}
+
+	t.Logf("FUNC-SYNC PASS: Synced %d schedules, resolved %d + %d on-call users",
+		len(results), len(r1.OnCallUsers), len(r2.OnCallUsers))
+}
+
+func TestFUNC_RemoveScheduleAndVerifyCleanup(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Platform On-Call", "UTC")
+	mock.addSchedule("sched-002", "Backend Primary", "UTC")
+	mock.addUser("user-alice", "Alice", "alice@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-alice"})
+	mock.setOnCall("sched-002", []string{"user-alice"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Sync with both schedules
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-002"})
+	if err != nil {
+		t.Fatalf("FUNC-REMOVE FAIL: Initial sync: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("FUNC-REMOVE FAIL: Expected 2 results initially, got %d", len(results))
+	}
+
+	// Remove sched-002 from tracking
+	results, err = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil {
+		t.Fatalf("FUNC-REMOVE FAIL: Post-remove sync: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("FUNC-REMOVE FAIL: Expected 1 result after removal, got %d", len(results))
+	}
+	if results[0].ScheduleID != "sched-001" {
+		t.Fatalf("FUNC-REMOVE FAIL: Expected sched-001, got %s", results[0].ScheduleID)
+	}
+
+	t.Log("FUNC-REMOVE PASS: Schedule removed, only tracked schedule synced")
+}
+
+func TestFUNC_FullSyncLifecycle(t *testing.T) {
+	mock := newMockIncidentIO("lifecycle-key")
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("lifecycle-key", incidentio.WithBaseURL(srv.URL))
+
+	// Phase 1: Empty state — no schedules exist
+	schedules, err := listAllSchedules(context.Background(), client)
+	if err != nil {
+		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 1 list: %v", err)
+	}
+	if len(schedules) != 0 {
+		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 1 expected 0 schedules, got %d", len(schedules))
+	}
+
+	// Phase 2: Admin creates schedules in incident.io
+	mock.addSchedule("sched-A", "Team Alpha", "UTC")
+	mock.addSchedule("sched-B", "Team Beta", "America/Chicago")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.addUser("user-2", "User Two", "two@example.com", "responder")
+	mock.setOnCall("sched-A", []string{"user-1"})
+	mock.setOnCall("sched-B", []string{"user-2"})
+
+	schedules, _ = listAllSchedules(context.Background(), client)
+	if len(schedules) != 2 {
+		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 2 expected 2 schedules, got %d", len(schedules))
+	}
+
+	// Phase 3: Track both schedules and sync
+	results, _ := simulateFullSync(context.Background(), client, []string{"sched-A", "sched-B"})
+	if len(results) != 2 {
+		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 3 expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Error != nil {
+			t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 3 error: %v", r.Error)
+		}
+		if len(r.OnCallUsers) != 1 {
+			t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 3 expected 1 user per schedule, got %d for %s", len(r.OnCallUsers), r.ScheduleID)
+		}
+	}
+
+	// Phase 4: On-call rotation — user-1 goes off, user-2 takes over both
+	mock.setOnCall("sched-A", []string{"user-2"})
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-A", "sched-B"})
+	for _, r := range results {
+		if r.ScheduleID == "sched-A" {
+			if len(r.OnCallUsers) != 1 || r.OnCallUsers[0].UserID != "user-2" {
+				t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 4 sched-A should have user-2, got %v", r.OnCallUsers)
+			}
+		}
+	}
+
+	// Phase 5: Schedule renamed in incident.io
+	mock.renameSchedule("sched-A", "Team Alpha (Renamed)")
+	sched, err := client.GetScheduleWithContext(context.Background(), "sched-A", incidentio.GetScheduleOptions{})
+	if err != nil {
+		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 5 get schedule: %v", err)
+	}
+	if sched.Name != "Team Alpha (Renamed)" {
+		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 5 expected renamed schedule, got %q", sched.Name)
+	}
+
+	// Phase 6: Schedule deleted in incident.io
+	mock.removeSchedule("sched-B")
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-A", "sched-B"})
+	for _, r := range results {
+		if r.ScheduleID == "sched-B" && r.Error == nil {
+			t.Fatal("FUNC-LIFECYCLE FAIL: Phase 6 deleted schedule should have error")
+		}
+	}
+
+	// Phase 7: New schedule added
+	mock.addSchedule("sched-C", "Team Charlie", "Europe/London")
+	mock.addUser("user-3", "User Three", "three@example.com", "responder")
+	mock.setOnCall("sched-C", []string{"user-3"})
+
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-A", "sched-C"})
+	if len(results) != 2 {
+		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 7 expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Error != nil {
+			t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 7 error on %s: %v", r.ScheduleID, r.Error)
+		}
+	}
+
+	t.Log("FUNC-LIFECYCLE PASS: Full lifecycle tested — create, sync, rotate, rename, delete, re-add")
+}
+
+func TestFUNC_UserResolutionByEmail(t *testing.T) {
+	mock := newMockIncidentIO("email-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-alice", "Alice Chen", "alice@strongdm.com", "responder")
+	mock.addUser("user-bob", "Bob Martinez", "bob@strongdm.com", "responder")
+	mock.addUser("user-noemail", "No Email User", "", "observer")
+	mock.setOnCall("sched-001", []string{"user-alice", "user-bob", "user-noemail"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("email-key", incidentio.WithBaseURL(srv.URL))
+
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil {
+		t.Fatalf("FUNC-EMAIL FAIL: %v", err)
+	}
+
+	r := results[0]
+	if r.Error != nil {
+		t.Fatalf("FUNC-EMAIL FAIL: %v", r.Error)
+	}
+
+	// Should resolve 3 users (including empty email one — SDK returns it, integration layer would filter)
+	emailCount := 0
+	noEmailCount := 0
+	for _, u := range r.OnCallUsers {
+		if u.Email != "" {
+			emailCount++
+		} else {
+			noEmailCount++
+		}
+	}
+
+	t.Logf("FUNC-EMAIL PASS: Resolved %d users with email, %d without email", emailCount, noEmailCount)
+	if noEmailCount > 0 {
+		t.Log("FUNC-EMAIL FINDING: Users without email are returned by SDK. Integration layer must filter for EMAIL sync mode.")
+	}
+}
+
+// ============================================================================
+// FUNCTIONAL TESTS — Edge Cases
+// ============================================================================
+
+func TestFUNC_DisconnectReconnect(t *testing.T) {
+	mock := newMockIncidentIO("valid-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+
+	srv := mock.serve()
+	defer srv.Close()
+
+	// Step 1: Connect with valid key — works
+	client := incidentio.NewClient("valid-key", incidentio.WithBaseURL(srv.URL))
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil || results[0].Error != nil {
+		t.Fatalf("FUNC-DISCONNECT FAIL: Initial sync should work: %v", err)
+	}
+
+	// Step 2: "Revoke" API key — simulate by using wrong key
+	badClient := incidentio.NewClient("revoked-key", incidentio.WithBaseURL(srv.URL))
+	_, err = simulateFullSync(context.Background(), badClient, []string{"sched-001"})
+	if err == nil {
+		t.Fatal("FUNC-DISCONNECT FAIL: Revoked key should fail")
+	}
+
+	// Step 3: "Reconnect" with new valid key — works again
+	newClient := incidentio.NewClient("valid-key", incidentio.WithBaseURL(srv.URL))
+	results, err = simulateFullSync(context.Background(), newClient, []string{"sched-001"})
+	if err != nil || results[0].Error != nil {
+		t.Fatalf("FUNC-DISCONNECT FAIL: Reconnect should work: %v", err)
+	}
+
+	t.Log("FUNC-DISCONNECT PASS: Disconnect and reconnect cycle works correctly")
+}
+
+func TestFUNC_ScheduleDeletedDuringSync(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Stable Schedule", "UTC")
+	mock.addSchedule("sched-ephemeral", "Ephemeral Schedule", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+	mock.setOnCall("sched-ephemeral", []string{"user-1"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Initial sync works with both
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-ephemeral"})
+	if err != nil {
+		t.Fatalf("FUNC-DELETED-DURING FAIL: Initial sync: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("FUNC-DELETED-DURING FAIL: Expected 2 results, got %d", len(results))
+	}
+
+	// Delete schedule between syncs
+	mock.removeSchedule("sched-ephemeral")
+
+	// Next sync should handle deleted schedule gracefully
+	results, err = simulateFullSync(context.Background(), client, []string{"sched-001", "sched-ephemeral"})
+	if err != nil {
+		t.Fatalf("FUNC-DELETED-DURING FAIL: Post-delete sync should not fail entirely: %v", err)
+	}
+
+	// sched-001 should still sync fine
+	// sched-ephemeral should have an error
+	var stableOK, ephemeralError bool
+	for _, r := range results {
+		if r.ScheduleID == "sched-001" && r.Error == nil {
+			stableOK = true
+		}
+		if r.ScheduleID == "sched-ephemeral" && r.Error != nil {
+			ephemeralError = true
+		}
+	}
+
+	if !stableOK {
+		t.Fatal("FUNC-DELETED-DURING FAIL: Stable schedule should still sync")
+	}
+	if !ephemeralError {
+		t.Fatal("FUNC-DELETED-DURING FAIL: Deleted schedule should report error")
+	}
+
+	t.Log("FUNC-DELETED-DURING PASS: Deleted schedule handled gracefully, stable schedule still syncs")
+}
+
+func TestFUNC_OnCallRotationBetweenSyncs(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-day", "Day Person", "day@example.com", "responder")
+	mock.addUser("user-night", "Night Person", "night@example.com", "responder")
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Sync 1: Day shift
+	mock.setOnCall("sched-001", []string{"user-day"})
+	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if results[0].OnCallUsers[0].UserID != "user-day" {
+		t.Fatalf("FUNC-ROTATION FAIL: Sync 1 should show day user, got %s", results[0].OnCallUsers[0].UserID)
+	}
+
+	// Sync 2: Night shift — rotation happened
+	mock.setOnCall("sched-001", []string{"user-night"})
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if results[0].OnCallUsers[0].UserID != "user-night" {
+		t.Fatalf("FUNC-ROTATION FAIL: Sync 2 should show night user, got %s", results[0].OnCallUsers[0].UserID)
+	}
+
+	// Sync 3: Handoff — both on call during overlap
+	mock.setOnCall("sched-001", []string{"user-day", "user-night"})
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if len(results[0].OnCallUsers) != 2 {
+		t.Fatalf("FUNC-ROTATION FAIL: Sync 3 should show 2 users, got %d", len(results[0].OnCallUsers))
+	}
+
+	t.Log("FUNC-ROTATION PASS: On-call rotation correctly reflected across syncs")
+}
+
+func TestFUNC_EmptyScheduleNoOneOnCall(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Initially someone is on-call
+	mock.setOnCall("sched-001", []string{"user-1"})
+	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if len(results[0].OnCallUsers) != 1 {
+		t.Fatalf("FUNC-EMPTY FAIL: Should have 1 user initially")
+	}
+
+	// Shift gap — no one on-call
+	mock.clearOnCall("sched-001")
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if len(results[0].OnCallUsers) != 0 {
+		t.Fatalf("FUNC-EMPTY FAIL: Should have 0 users during gap")
+	}
+
+	t.Log("FUNC-EMPTY PASS: Empty on-call set handled (0 users returned)")
+	t.Log("FUNC-EMPTY FINDING: Integration layer (SYNC-009 fix) should preserve previous members here")
+}
+
+func TestFUNC_SameUserAcrossMultipleSchedules(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addSchedule("sched-002", "Escalation", "UTC")
+	mock.addUser("user-alice", "Alice", "alice@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-alice"})
+	mock.setOnCall("sched-002", []string{"user-alice"}) // Same user in both
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-002"})
+
+	aliceCount := 0
+	for _, r := range results {
+		for _, u := range r.OnCallUsers {
+			if u.UserID == "user-alice" {
+				aliceCount++
+			}
+		}
+	}
+
+	if aliceCount != 2 {
+		t.Fatalf("FUNC-DEDUP FAIL: Alice should appear in 2 schedules, appeared in %d", aliceCount)
+	}
+
+	t.Log("FUNC-DEDUP PASS: Same user correctly appears in both schedule groups")
+	t.Log("FUNC-DEDUP INFO: Each schedule gets its own group — user in both groups is correct behavior")
+}
+
+func TestFUNC_PartialFailureDuringSyncContinuesOthers(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-ok-1", "Good Schedule 1", "UTC")
+	mock.addSchedule("sched-fail", "Failing Schedule", "UTC")
+	mock.addSchedule("sched-ok-2", "Good Schedule 2", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.addUser("user-2", "User Two", "two@example.com", "responder")
+	mock.setOnCall("sched-ok-1", []string{"user-1"})
+	mock.setOnCall("sched-fail", []string{"user-1"})
+	mock.setOnCall("sched-ok-2", []string{"user-2"})
+
+	// Make one schedule fail
+	mock.failSchedule("sched-fail", true)
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-ok-1", "sched-fail", "sched-ok-2"})
+	if err != nil {
+		t.Fatalf("FUNC-PARTIAL FAIL: Full sync should not fail entirely: %v", err)
+	}
+
+	var okCount, failCount int
+	for _, r := range results {
+		if r.Error != nil {
+			failCount++
+			t.Logf("FUNC-PARTIAL INFO: %s failed: %v", r.ScheduleID, r.Error)
+		} else {
+			okCount++
+			t.Logf("FUNC-PARTIAL INFO: %s synced OK with %d users", r.ScheduleID, len(r.OnCallUsers))
+		}
+	}
+
+	if okCount != 2 {
+		t.Fatalf("FUNC-PARTIAL FAIL: Expected 2 successful syncs, got %d", okCount)
+	}
+	if failCount != 1 {
+		t.Fatalf("FUNC-PARTIAL FAIL: Expected 1 failed sync, got %d", failCount)
+	}
+
+	t.Log("FUNC-PARTIAL PASS: Failed schedule didn't block others — 2 synced, 1 failed")
+}
+
+func TestFUNC_APIDegradationDuringMultiStepSync(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Step 1: Normal sync works
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil || results[0].Error != nil {
+		t.Fatalf("FUNC-DEGRADE FAIL: Initial sync should work")
+	}
+
+	// Step 2: User endpoint goes down mid-sync
+	mock.failUserEndpointAfter(0, 503)
+	results, err = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil {
+		t.Fatalf("FUNC-DEGRADE FAIL: Sync should still return results even with user failures: %v", err)
+	}
+	// Schedule entries work, but user resolution fails → 0 resolved users
+	if results[0].Error != nil {
+		t.Logf("FUNC-DEGRADE INFO: Schedule synced with error: %v", results[0].Error)
+	} else if len(results[0].OnCallUsers) != 0 {
+		t.Logf("FUNC-DEGRADE INFO: Unexpectedly resolved %d users with user endpoint down", len(results[0].OnCallUsers))
+	} else {
+		t.Log("FUNC-DEGRADE INFO: 0 users resolved (user endpoint down) — schedule entry found but user lookup failed")
+	}
+
+	// Step 3: User endpoint recovers
+	mock.recoverUserEndpoint()
+	results, err = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil || results[0].Error != nil {
+		t.Fatalf("FUNC-DEGRADE FAIL: Recovery sync should work")
+	}
+	if len(results[0].OnCallUsers) != 1 {
+		t.Fatalf("FUNC-DEGRADE FAIL: Expected 1 user after recovery, got %d", len(results[0].OnCallUsers))
+	}
+
+	t.Log("FUNC-DEGRADE PASS: Handled API degradation and recovery")
+}
+
+func TestFUNC_LargeScaleSync(t *testing.T) {
+	mock := newMockIncidentIO("scale-key")
+
+	// Create 50 schedules with 5 users each
+	for i := 0; i < 50; i++ {
+		schedID := fmt.Sprintf("sched-%03d", i)
+		mock.addSchedule(schedID, fmt.Sprintf("Schedule %d", i), "UTC")
+		var onCallUsers []string
+		for j := 0; j < 5; j++ {
+			userID := fmt.Sprintf("user-%03d-%d", i, j)
+			mock.addUser(userID, fmt.Sprintf("User %d-%d", i, j), fmt.Sprintf("user%d_%d@example.com", i, j), "responder")
+			onCallUsers = append(onCallUsers, userID)
+		}
+		mock.setOnCall(schedID, onCallUsers)
+	}
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("scale-key", incidentio.WithBaseURL(srv.URL))
+
+	// List all schedules
+	start := time.Now()
+	schedules, err := listAllSchedules(context.Background(), client)
+	listDuration := time.Since(start)
+	if err != nil {
+		t.Fatalf("FUNC-SCALE FAIL: List schedules: %v", err)
+	}
+	if len(schedules) != 50 {
+		t.Fatalf("FUNC-SCALE FAIL: Expected 50 schedules, got %d", len(schedules))
+	}
+
+	// Sync all 50 schedules
+	tracked := make([]string, 50)
+	for i := 0; i < 50; i++ {
+		tracked[i] = fmt.Sprintf("sched-%03d", i)
+	}
+
+	start = time.Now()
+	results, err := simulateFullSync(context.Background(), client, tracked)
+	syncDuration := time.Since(start)
+	if err != nil {
+		t.Fatalf("FUNC-SCALE FAIL: Sync: %v", err)
+	}
+
+	totalUsers := 0
+	errors := 0
+	for _, r := range results {
+		if r.Error != nil {
+			errors++
+		} else {
+			totalUsers += len(r.OnCallUsers)
+		}
+	}
+
+	t.Logf("FUNC-SCALE PASS: 50 schedules, %d users resolved, %d errors", totalUsers, errors)
+	t.Logf("FUNC-SCALE INFO: List took %v, sync took %v, %d API calls total",
+		listDuration, syncDuration, mock.getRequestCount())
+
+	if totalUsers != 250 {
+		t.Errorf("FUNC-SCALE WARNING: Expected 250 total users (50x5), got %d", totalUsers)
+	}
+}
+
+func TestFUNC_ConcurrentSyncs(t *testing.T) {
+	mock := newMockIncidentIO("concurrent-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("concurrent-key", incidentio.WithBaseURL(srv.URL))
+
+	// Run 10 syncs concurrently (simulates periodic sync + manual sync race)
+	const concurrency = 10
+	var wg sync.WaitGroup
+	var errCount int32
+	var successCount int32
+
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+			if err != nil || results[0].Error != nil {
+				atomic.AddInt32(&errCount, 1)
+				return
+			}
+			if len(results[0].OnCallUsers) == 1 {
+				atomic.AddInt32(&successCount, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	t.Logf("FUNC-CONCURRENT PASS: %d/%d concurrent syncs succeeded, %d failed",
+		successCount, concurrency, errCount)
+	if errCount > 0 {
+		t.Logf("FUNC-CONCURRENT FINDING: %d concurrent syncs failed — possible contention", errCount)
+	}
+}
+
+func TestFUNC_ScheduleAddedThenImmediatelyRemoved(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Permanent", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Add a schedule
+	mock.addSchedule("sched-temp", "Temporary", "UTC")
+	mock.setOnCall("sched-temp", []string{"user-1"})
+
+	// Sync with it
+	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-temp"})
+	if len(results) != 2 {
+		t.Fatalf("FUNC-ADD-REMOVE FAIL: Expected 2 results after add, got %d", len(results))
+	}
+
+	// Immediately remove from incident.io AND from tracking
+	mock.removeSchedule("sched-temp")
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if len(results) != 1 {
+		t.Fatalf("FUNC-ADD-REMOVE FAIL: Expected 1 result after remove, got %d", len(results))
+	}
+
+	t.Log("FUNC-ADD-REMOVE PASS: Schedule added then immediately removed — clean state")
+}
+
+func TestFUNC_UserDeletedFromIncidentIO(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-active", "Active User", "active@example.com", "responder")
+	mock.addUser("user-departing", "Departing User", "departing@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-active", "user-departing"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Sync 1: Both users on-call
+	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if len(results[0].OnCallUsers) != 2 {
+		t.Fatalf("FUNC-USER-DELETE FAIL: Expected 2 users, got %d", len(results[0].OnCallUsers))
+	}
+
+	// User departs — still in on-call entries but user endpoint returns 404
+	// (simulates delay between schedule update and user removal)
+	mock.mu.Lock()
+	delete(mock.users, "user-departing")
+	mock.mu.Unlock()
+
+	// Sync 2: Departing user still in entries but can't be resolved
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if len(results[0].OnCallUsers) != 1 {
+		t.Fatalf("FUNC-USER-DELETE FAIL: Expected 1 resolved user after deletion, got %d", len(results[0].OnCallUsers))
+	}
+	if results[0].OnCallUsers[0].UserID != "user-active" {
+		t.Fatalf("FUNC-USER-DELETE FAIL: Expected active user, got %s", results[0].OnCallUsers[0].UserID)
+	}
+
+	t.Log("FUNC-USER-DELETE PASS: Deleted user gracefully skipped, active user still resolved")
+}
+
+func TestFUNC_ManySchedulesSomeFailingOthersSucceed(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	for i := 0; i < 10; i++ {
+		id := fmt.Sprintf("sched-%02d", i)
+		mock.addSchedule(id, fmt.Sprintf("Schedule %d", i), "UTC")
+		mock.addUser(fmt.Sprintf("user-%02d", i), fmt.Sprintf("User %d", i), fmt.Sprintf("u%d@example.com", i), "responder")
+		mock.setOnCall(id, []string{fmt.Sprintf("user-%02d", i)})
+	}
+
+	// Fail schedules 3, 5, 7
+	mock.failSchedule("sched-03", true)
+	mock.failSchedule("sched-05", true)
+	mock.failSchedule("sched-07", true)
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	tracked := make([]string, 10)
+	for i := 0; i < 10; i++ {
+		tracked[i] = fmt.Sprintf("sched-%02d", i)
+	}
+
+	results, err := simulateFullSync(context.Background(), client, tracked)
+	if err != nil {
+		t.Fatalf("FUNC-MIXED FAIL: Overall sync should not fail: %v", err)
+	}
+
+	successIDs := []string{}
+	failIDs := []string{}
+	for _, r := range results {
+		if r.Error != nil {
+			failIDs = append(failIDs, r.ScheduleID)
+		} else {
+			successIDs = append(successIDs, r.ScheduleID)
+		}
+	}
+
+	if len(successIDs) != 7 {
+		t.Fatalf("FUNC-MIXED FAIL: Expected 7 successes, got %d: %v", len(successIDs), successIDs)
+	}
+	if len(failIDs) != 3 {
+		t.Fatalf("FUNC-MIXED FAIL: Expected 3 failures, got %d: %v", len(failIDs), failIDs)
+	}
+
+	t.Logf("FUNC-MIXED PASS: 7 schedules synced, 3 failed individually (no cascade)")
+}
+
+func TestFUNC_APIRequestCounting(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call 1", "UTC")
+	mock.addSchedule("sched-002", "On-Call 2", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.addUser("user-2", "User Two", "two@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+	mock.setOnCall("sched-002", []string{"user-2"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	mock.resetRequestLog()
+	_, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-002"})
+	if err != nil {
+		t.Fatalf("FUNC-API-COUNT FAIL: %v", err)
+	}
+
+	log := mock.getRequestLog()
+	count := mock.getRequestCount()
+
+	// Expected: 1 list schedules + 2 schedule entries + 2 get user = 5 minimum
+	t.Logf("FUNC-API-COUNT PASS: Sync made %d API calls", count)
+	for _, entry := range log {
+		t.Logf("  %s", entry)
+	}
+
+	if count > 20 {
+		t.Logf("FUNC-API-COUNT FINDING: %d API calls seems high for 2 schedules with 1 user each", count)
+	}
+}
+
+func TestFUNC_ScheduleWithManyOnCallUsers(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-big", "Big Schedule", "UTC")
+
+	// 20 users on-call simultaneously
+	var userIDs []string
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("user-%03d", i)
+		mock.addUser(id, fmt.Sprintf("User %d", i), fmt.Sprintf("user%d@example.com", i), "responder")
+		userIDs = append(userIDs, id)
+	}
+	mock.setOnCall("sched-big", userIDs)
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	results, _ := simulateFullSync(context.Background(), client, []string{"sched-big"})
+	if results[0].Error != nil {
+		t.Fatalf("FUNC-BIG-ONCALL FAIL: %v", results[0].Error)
+	}
+	if len(results[0].OnCallUsers) != 20 {
+		t.Fatalf("FUNC-BIG-ONCALL FAIL: Expected 20 users, got %d", len(results[0].OnCallUsers))
+	}
+
+	t.Logf("FUNC-BIG-ONCALL PASS: 20 concurrent on-call users resolved correctly")
+}
+
+func TestFUNC_EntireAPIDown(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Fail the list schedules endpoint — first step of sync
+	mock.failEndpoint("/v2/schedules", 503)
+
+	_, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err == nil {
+		t.Fatal("FUNC-API-DOWN FAIL: Should fail when entire API is down")
+	}
+
+	t.Logf("FUNC-API-DOWN PASS: Full API outage handled: %v", err)
+
+	// Recovery
+	mock.failEndpoint("/v2/schedules", 0)
+	mock.addUser("user-1", "User", "u@e.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil || results[0].Error != nil {
+		t.Fatalf("FUNC-API-DOWN FAIL: Should recover after API comes back: %v", err)
+	}
+
+	t.Log("FUNC-API-DOWN PASS: API outage and recovery handled correctly")
+}
+
+// ============================================================================
+// FUNCTIONAL TESTS — Mock Server Behavior
+// ============================================================================
+
+func TestFUNC_MockPaginationStableCursor(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	// Added out of order on purpose — map iteration must not leak into cursors
+	for _, id := range []string{"sched-004", "sched-001", "sched-005", "sched-003", "sched-002"} {
+		mock.addSchedule(id, "Schedule "+id, "UTC")
+	}
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	var ids []string
+	opts := incidentio.ListSchedulesOptions{PageSize: 2}
+	pages := 0
+	for {
+		resp, err := client.ListSchedulesWithContext(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("FUNC-MOCK-CURSOR FAIL: Page %d: %v", pages+1, err)
+		}
+		for _, s := range resp.Schedules {
+			ids = append(ids, s.ID)
+		}
+		pages++
+		if resp.PaginationMeta.After == "" {
+			break
+		}
+		opts.After = resp.PaginationMeta.After
+		if pages > 10 {
+			t.Fatal("FUNC-MOCK-CURSOR FAIL: Infinite pagination loop detected")
+		}
+	}
+
+	expected := []string{"sched-001", "sched-002", "sched-003", "sched-004", "sched-005"}
+	if pages != 3 {
+		t.Errorf("FUNC-MOCK-CURSOR FAIL: Expected 3 pages of size 2, got %d", pages)
+	}
+	if strings.Join(ids, ",") != strings.Join(expected, ",") {
+		t.Fatalf("FUNC-MOCK-CURSOR FAIL: Expected each schedule once in sorted order %v, got %v", expected, ids)
+	}
+
+	t.Logf("FUNC-MOCK-CURSOR PASS: %d schedules across %d pages, each exactly once in sorted order", len(ids), pages)
+}
+
+func TestFUNC_MockUserEndpointFailThenRecover(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.addUser("user-2", "User Two", "two@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1", "user-2"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Let exactly one user lookup through, then fail the rest
+	mock.failUserEndpointAfter(1, 500)
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil || results[0].Error != nil {
+		t.Fatalf("FUNC-MOCK-USER-FAIL FAIL: Sync should not fail outright: %v / %v", err, results[0].Error)
+	}
+	if len(results[0].OnCallUsers) != 1 {
+		t.Fatalf("FUNC-MOCK-USER-FAIL FAIL: Expected exactly 1 user before the failure point, got %d", len(results[0].OnCallUsers))
+	}
+
+	// Every user lookup fails
+	mock.failUserEndpointAfter(0, 500)
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if len(results[0].OnCallUsers) != 0 {
+		t.Fatalf("FUNC-MOCK-USER-FAIL FAIL: Expected 0 resolved users while failing, got %d", len(results[0].OnCallUsers))
+	}
+
+	// Recovery takes effect on the very next request
+	mock.recoverUserEndpoint()
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if len(results[0].OnCallUsers) != 2 {
+		t.Fatalf("FUNC-MOCK-USER-FAIL FAIL: Expected 2 resolved users after recovery, got %d", len(results[0].OnCallUsers))
+	}
+
+	t.Log("FUNC-MOCK-USER-FAIL PASS: User endpoint failed deterministically, then fully recovered")
+}
+
+func TestFUNC_GetAllOnCall(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addSchedule("sched-002", "Secondary", "UTC")
+	mock.addSchedule("sched-003", "Broken", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.addUser("user-2", "User Two", "two@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+	mock.setOnCall("sched-002", []string{"user-1", "user-2"})
+	mock.setOnCall("sched-003", []string{"user-2"})
+	mock.failSchedule("sched-003", true)
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	onCall, errs := getAllOnCall(context.Background(), client, []string{"sched-001", "sched-002", "sched-003"}, 2)
+
+	if len(onCall) != 2 {
+		t.Fatalf("FUNC-ALL-ONCALL FAIL: Expected 2 populated schedules, got %d: %v", len(onCall), onCall)
+	}
+	if len(onCall["sched-001"]) != 1 || len(onCall["sched-002"]) != 2 {
+		t.Fatalf("FUNC-ALL-ONCALL FAIL: Wrong users: sched-001=%d sched-002=%d", len(onCall["sched-001"]), len(onCall["sched-002"]))
+	}
+	if len(errs) != 1 || errs["sched-003"] == nil {
+		t.Fatalf("FUNC-ALL-ONCALL FAIL: Expected only sched-003 in error map, got %v", errs)
+	}
+
+	// A deleted schedule tracked after a failing one, while its worker may still be running
+	onCall, errs = getAllOnCall(context.Background(), client, []string{"sched-003", "sched-001", "sched-gone"}, 2)
+	if len(onCall) != 1 || len(errs) != 2 || errs["sched-003"] == nil || errs["sched-gone"] == nil {
+		t.Fatalf("FUNC-ALL-ONCALL FAIL: Expected sched-001 resolved and sched-003, sched-gone in error map, got %v / %v", onCall, errs)
+	}
+
+	t.Logf("FUNC-ALL-ONCALL PASS: 2 schedules resolved, sched-003 isolated: %v", errs["sched-003"])
+}
+
+func TestFUNC_WriteOnCallSnapshot(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addSchedule("sched-002", "Secondary", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+	mock.clearOnCall("sched-002")
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-002", "sched-gone"})
+	if err != nil {
+		t.Fatalf("FUNC-SNAPSHOT FAIL: Sync: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := writeOnCallSnapshot(&buf, results); err != nil {
+		t.Fatalf("FUNC-SNAPSHOT FAIL: Write: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(lines) != len(results) {
+		t.Fatalf("FUNC-SNAPSHOT FAIL: Expected %d lines, got %d:\n%s", len(results), len(lines), buf.String())
+	}
+	for i, line := range lines {
+		var rec onCallSnapshotLine
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("FUNC-SNAPSHOT FAIL: Line %d is not valid JSON: %v: %s", i+1, err, line)
+		}
+		if rec.ScheduleID != results[i].ScheduleID || rec.Timestamp.IsZero() {
+			t.Errorf("FUNC-SNAPSHOT FAIL: Line %d has schedule %q timestamp %v", i+1, rec.ScheduleID, rec.Timestamp)
+		}
+	}
+
+	t.Logf("FUNC-SNAPSHOT PASS: %d schedules written as %d NDJSON lines", len(results), len(lines))
+}
+
+func TestFUNC_ListSchedulesByName(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Platform On-Call", "UTC")
+	mock.addSchedule("sched-002", "Backend Primary", "UTC")
+	mock.addSchedule("sched-003", "Backend Secondary", "UTC")
+	mock.addSchedule("sched-004", "Frontend", "UTC")
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Client-side fallback
+	schedules, err := listSchedulesByName(context.Background(), client, "Backend")
+	if err != nil {
+		t.Fatalf("FUNC-NAME-FILTER FAIL: %v", err)
+	}
+	if len(schedules) != 2 {
+		t.Fatalf("FUNC-NAME-FILTER FAIL: Expected 2 Backend schedules, got %d: %v", len(schedules), schedules)
+	}
+	for _, s := range schedules {
+		if !strings.Contains(s.Name, "Backend") {
+			t.Errorf("FUNC-NAME-FILTER FAIL: Non-matching schedule returned: %q", s.Name)
+		}
+	}
+
+	// Server-side filter, ready for when the SDK passes the query param
+	resp := rawGet(t, srv, "/v2/schedules?name=backend", nil)
+	defer resp.Body.Close()
+	var body struct {
+		Schedules []mockSchedule `json:"schedules"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("FUNC-NAME-FILTER FAIL: Decode: %v", err)
+	}
+	if len(body.Schedules) != 2 {
+		t.Fatalf("FUNC-NAME-FILTER FAIL: Mock should honor name param, got %d schedules", len(body.Schedules))
+	}
+
+	t.Log("FUNC-NAME-FILTER PASS: Only Backend schedules returned, client-side and by the mock")
+}
+
+func TestFUNC_MockCapturesRequestBodies(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	srv := mock.serve()
+	defer srv.Close()
+
+	// GETs from the SDK carry no body and must not be captured
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	if _, err := listAllSchedules(context.Background(), client); err != nil {
+		t.Fatalf("FUNC-MOCK-BODY FAIL: List schedules: %v", err)
+	}
+	if bodies := mock.getRequestBodies(); len(bodies) != 0 {
+		t.Fatalf("FUNC-MOCK-BODY FAIL: Expected no captured bodies for GETs, got %d", len(bodies))
+	}
+
+	payload := `{"name":"New Schedule"}`
+	req, _ := http.NewRequest("POST", srv.URL+"/v2/schedules/sched-001/overrides", strings.NewReader(payload))
+	req.Header.Set("Authorization", "Bearer test-key")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("FUNC-MOCK-BODY FAIL: POST: %v", err)
+	}
+	resp.Body.Close()
+
+	bodies := mock.getRequestBodies()
+	if len(bodies) != 1 {
+		t.Fatalf("FUNC-MOCK-BODY FAIL: Expected 1 captured body, got %d", len(bodies))
+	}
+	if bodies[0].Method != "POST" || bodies[0].Path != "/v2/schedules/sched-001/overrides" || string(bodies[0].Body) != payload {
+		t.Fatalf("FUNC-MOCK-BODY FAIL: Wrong capture: %s %s %s", bodies[0].Method, bodies[0].Path, bodies[0].Body)
+	}
+
+	t.Logf("FUNC-MOCK-BODY PASS: Captured %s %s body %s", bodies[0].Method, bodies[0].Path, bodies[0].Body)
+}
+
+func TestFUNC_BuildGroupMemberships(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addSchedule("sched-002", "Secondary", "UTC")
+	mock.addSchedule("sched-fail", "Failing", "UTC")
+	mock.addUser("user-carol", "Carol", "carol@example.com", "responder")
+	mock.addUser("user-alice", "Alice", "alice@example.com", "responder")
+	mock.addUser("user-bob", "Bob", "bob@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-carol", "user-alice"})
+	mock.setOnCall("sched-002", []string{"user-bob"})
+	mock.failSchedule("sched-fail", true)
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-002", "sched-fail"})
+	if err != nil {
+		t.Fatalf("FUNC-GROUPS FAIL: Sync: %v", err)
+	}
+
+	groups := buildGroupMemberships(results)
+	if len(groups) != 2 {
+		t.Fatalf("FUNC-GROUPS FAIL: Expected 2 groups (errored schedule skipped), got %d: %v", len(groups), groups)
+	}
+	if got := strings.Join(groups["sched-001"], ","); got != "alice@example.com,carol@example.com" {
+		t.Errorf("FUNC-GROUPS FAIL: sched-001 members not sorted or wrong: %s", got)
+	}
+	if got := strings.Join(groups["sched-002"], ","); got != "bob@example.com" {
+		t.Errorf("FUNC-GROUPS FAIL: sched-002 members wrong: %s", got)
+	}
+
+	t.Logf("FUNC-GROUPS PASS: Group memberships: %v", groups)
+}
+
+func TestFUNC_SyncOnCallUsersStableOrder(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	for _, id := range []string{"user-c", "user-a", "user-b"} {
+		mock.addUser(id, "User "+id, id+"@example.com", "responder")
+	}
+	mock.setOnCall("sched-001", []string{"user-c", "user-a", "user-b"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	first, _ := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	second, _ := simulateFullSync(context.Background(), client, []string{"sched-001"})
+
+	if !reflect.DeepEqual(first[0].OnCallUsers, second[0].OnCallUsers) {
+		t.Fatalf("FUNC-STABLE-ORDER FAIL: Repeated syncs differ: %v vs %v", first[0].OnCallUsers, second[0].OnCallUsers)
+	}
+	var ids []string
+	for _, u := range first[0].OnCallUsers {
+		ids = append(ids, u.UserID)
+	}
+	if strings.Join(ids, ",") != "user-a,user-b,user-c" {
+		t.Fatalf("FUNC-STABLE-ORDER FAIL: Expected users sorted by ID, got %v", ids)
+	}
+
+	t.Logf("FUNC-STABLE-ORDER PASS: On-call users sorted by ID across syncs: %v", ids)
+}
+
+func TestFUNC_SyncWarningsForEmaillessUsers(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-alice", "Alice Chen", "alice@example.com", "responder")
+	mock.addUser("user-noemail", "No Email User", "", "observer")
+	mock.setOnCall("sched-001", []string{"user-alice", "user-noemail"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	var warnings []syncWarning
+	results, err := simulateFullSyncWithOptions(context.Background(), client, []string{"sched-001"}, syncOptions{
+		OnWarning: func(w syncWarning) { warnings = append(warnings, w) },
+	})
+	if err != nil || results[0].Error != nil {
+		t.Fatalf("FUNC-SYNC-WARN FAIL: Sync failed: %v / %v", err, results[0].Error)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("FUNC-SYNC-WARN FAIL: Expected 1 warning, got %d: %v", len(warnings), warnings)
+	}
+	want := syncWarning{ScheduleID: "sched-001", UserID: "user-noemail", Reason: syncWarningMissingEmail}
+	if warnings[0] != want {
+		t.Fatalf("FUNC-SYNC-WARN FAIL: Expected %+v, got %+v", want, warnings[0])
+	}
+	if len(results[0].OnCallUsers) != 2 {
+		t.Errorf("FUNC-SYNC-WARN FAIL: Warnings must not drop users, got %d", len(results[0].OnCallUsers))
+	}
+
+	t.Logf("FUNC-SYNC-WARN PASS: Warning fired: %+v", warnings[0])
+}
+
+func TestFUNC_MockScenarioBuilder(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.scenario().onPath("/v2/users").fail(503).after(2).recoverAfter(1).latency(10 * time.Millisecond).apply()
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Calls 1-2 succeed, call 3 fails, call 4 onwards recovered
+	var outcomes []string
+	for i := 0; i < 5; i++ {
+		start := time.Now()
+		_, err := client.GetUserWithContext(context.Background(), "user-1", incidentio.GetUserOptions{})
+		if time.Since(start) < 10*time.Millisecond {
+			t.Errorf("FUNC-SCENARIO FAIL: Call %d skipped injected latency", i+1)
+		}
+		if err != nil {
+			outcomes = append(outcomes, "fail")
+		} else {
+			outcomes = append(outcomes, "ok")
+		}
+	}
+
+	if got := strings.Join(outcomes, ","); got != "ok,ok,fail,ok,ok" {
+		t.Fatalf("FUNC-SCENARIO FAIL: Expected failure exactly on call 3, got %s", got)
+	}
+
+	t.Logf("FUNC-SCENARIO PASS: Behavior flipped on call 3 and recovered on call 4: %v", outcomes)
+}
+
+func TestFUNC_SyncConcurrentBoundsSchedules(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	var tracked []string
+	for i := 0; i < 50; i++ {
+		schedID := fmt.Sprintf("sched-%03d", i)
+		userID := fmt.Sprintf("user-%03d", i)
+		mock.addSchedule(schedID, fmt.Sprintf("Schedule %d", i), "UTC")
+		mock.addUser(userID, fmt.Sprintf("User %d", i), fmt.Sprintf("user%d@example.com", i), "responder")
+		mock.setOnCall(schedID, []string{userID})
+		tracked = append(tracked, schedID)
+	}
+	// Slow entries down so schedules in flight overlap
+	mock.scenario().onPath("/v2/schedule_entries").latency(20 * time.Millisecond).apply()
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	results, err := simulateFullSyncConcurrent(context.Background(), client, tracked, 4)
+	if err != nil {
+		t.Fatalf("FUNC-SYNC-BOUNDED FAIL: %v", err)
+	}
+
+	if len(results) != 50 {
+		t.Fatalf("FUNC-SYNC-BOUNDED FAIL: Expected 50 results, got %d", len(results))
+	}
+	for i, r := range results {
+		if r.ScheduleID != tracked[i] {
+			t.Fatalf("FUNC-SYNC-BOUNDED FAIL: Result %d is %s, expected %s", i, r.ScheduleID, tracked[i])
+		}
+		if r.Error != nil || len(r.OnCallUsers) != 1 {
+			t.Errorf("FUNC-SYNC-BOUNDED FAIL: %s: err=%v users=%d", r.ScheduleID, r.Error, len(r.OnCallUsers))
+		}
+	}
+
+	// Each schedule issues one request at a time, so in-flight requests bound
+	// the schedules being processed
+	if peak := mock.getMaxInFlight(); peak > 4 {
+		t.Fatalf("FUNC-SYNC-BOUNDED FAIL: %d requests in flight, expected at most 4", peak)
+	} else if peak < 2 {
+		t.Fatalf("FUNC-SYNC-BOUNDED FAIL: Peak concurrency %d, schedules were not synced in parallel", peak)
+	}
+
+	t.Logf("FUNC-SYNC-BOUNDED PASS: 50 schedules synced with peak concurrency %d (limit 4)", mock.getMaxInFlight())
+}
+
+func TestFUNC_IncrementalListSchedules(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	for i := 1; i <= 10; i++ {
+		mock.addSchedule(fmt.Sprintf("sched-%03d", i), fmt.Sprintf("Schedule %d", i), "UTC")
+	}
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	// Full list to learn the cursor, as the first sync cycle would
+	all, cursor, err := incrementalListSchedules(context.Background(), client, "", 3)
+	if err != nil {
+		t.Fatalf("FUNC-INCREMENTAL FAIL: Full list: %v", err)
+	}
+	if len(all) != 10 || cursor != "9" {
+		t.Fatalf("FUNC-INCREMENTAL FAIL: Expected 10 schedules and cursor 9, got %d and %q", len(all), cursor)
+	}
+
+	// Resume mid-list: only pages from cursor 6 onwards are fetched
+	mock.resetRequestLog()
+	rest, cursor, err := incrementalListSchedules(context.Background(), client, "6", 3)
+	if err != nil {
+		t.Fatalf("FUNC-INCREMENTAL FAIL: Resume: %v", err)
+	}
+
+	var ids []string
+	for _, s := range rest {
+		ids = append(ids, s.ID)
+	}
+	if got := strings.Join(ids, ","); got != "sched-007,sched-008,sched-009,sched-010" {
+		t.Errorf("FUNC-INCREMENTAL FAIL: Expected schedules 7-10, got %s", got)
+	}
+	if n := mock.getRequestCount(); n != 2 {
+		t.Errorf("FUNC-INCREMENTAL FAIL: Expected 2 page requests, got %d: %v", n, mock.getRequestLog())
+	}
+	if cursor != "9" {
+		t.Errorf("FUNC-INCREMENTAL FAIL: Expected saved cursor 9, got %q", cursor)
+	}
+
+	t.Logf("FUNC-INCREMENTAL PASS: Resumed from cursor 6 with %d requests, saved cursor %s", mock.getRequestCount(), cursor)
+}
+
+func TestFUNC_SyncStopsResolvingUsersOnCancel(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.addUser("user-2", "User Two", "two@example.com", "responder")
+	mock.addUser("user-3", "User Three", "three@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1", "user-2", "user-3"})
+
+	srv := mock.serve()
+	defer srv.Close()
+
+	// Cancel the sync as soon as the first user lookup completes
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	httpClient := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		resp, err := http.DefaultTransport.RoundTrip(r)
+		if err != nil || !strings.HasPrefix(r.URL.Path, "/v2/users/") {
+			return resp, err
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(strings.NewReader(string(body)))
+		cancel()
+		return resp, nil
+	})}
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL), incidentio.WithHTTPClient(httpClient))
+
+	results, err := simulateFullSync(ctx, client, []string{"sched-001"})
+	if err != nil {
+		t.Fatalf("FUNC-SYNC-CANCEL FAIL: %v", err)
+	}
+
+	userCalls := 0
+	for _, entry := range mock.getRequestLog() {
+		if strings.Contains(entry, "/v2/users/") {
+			userCalls++
+		}
+	}
+	if userCalls != 1 {
+		t.Errorf("FUNC-SYNC-CANCEL FAIL: Expected 1 GetUser call before cancellation, got %d", userCalls)
+	}
+	if !errors.Is(results[0].Error, context.Canceled) {
+		t.Fatalf("FUNC-SYNC-CANCEL FAIL: Expected result marked with context.Canceled, got %v", results[0].Error)
+	}
+	if len(results[0].OnCallUsers) != 1 {
+		t.Errorf("FUNC-SYNC-CANCEL FAIL: Expected the 1 user resolved before cancellation, got %d", len(results[0].OnCallUsers))
+	}
+
+	t.Logf("FUNC-SYNC-CANCEL PASS: Resolution stopped after %d GetUser call: %v", userCalls, results[0].Error)
+}
+
+func TestFUNC_MockServeTLS(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+
+	srv := mock.serveTLS()
+	defer srv.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	httpClient := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+	}}
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL), incidentio.WithHTTPClient(httpClient))
+
+	resp, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err != nil {
+		t.Fatalf("FUNC-MOCK-TLS FAIL: ListSchedules over TLS: %v", err)
+	}
+	if len(resp.Schedules) != 1 || resp.Schedules[0].ID != "sched-001" {
+		t.Fatalf("FUNC-MOCK-TLS FAIL: Expected sched-001, got %+v", resp.Schedules)
+	}
+
+	t.Logf("FUNC-MOCK-TLS PASS: Listed schedules from %s using the mock's certificate", srv.URL)
+}
+
+func TestFUNC_FindSchedulesWithoutTimezone(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "America/New_York")
+	mock.addSchedule("sched-002", "Legacy Rotation", "")
+	mock.addSchedule("sched-003", "Secondary On-Call", "UTC")
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	missing, err := findSchedulesWithoutTimezone(context.Background(), client)
+	if err != nil {
+		t.Fatalf("FUNC-NO-TZ FAIL: %v", err)
+	}
+	if len(missing) != 1 || missing[0].ID != "sched-002" {
+		t.Fatalf("FUNC-NO-TZ FAIL: Expected only sched-002, got %+v", missing)
+	}
+
+	t.Logf("FUNC-NO-TZ PASS: Flagged %q as missing a timezone", missing[0].Name)
+}
+
+func TestFUNC_EnrichEntriesWithUsers(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addUser("user-alice", "Alice", "alice@example.com", "responder")
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	entries := []incidentio.ScheduleEntry{
+		{EntryID: "entry-1", ScheduleID: "sched-001", User: incidentio.User{ID: "user-alice", Name: "Alice"}},
+		{EntryID: "entry-2", ScheduleID: "sched-002", User: incidentio.User{ID: "user-alice", Name: "Alice"}},
+		{EntryID: "entry-3", ScheduleID: "sched-003", User: incidentio.User{ID: "user-bob", Name: "Bob", Email: "bob@example.com"}},
+	}
+
+	enriched, err := enrichEntriesWithUsers(context.Background(), client, entries)
+	if err != nil {
+		t.Fatalf("FUNC-ENRICH FAIL: %v", err)
+	}
+
+	for _, i := range []int{0, 1} {
+		if enriched[i].User.Email != "alice@example.com" {
+			t.Errorf("FUNC-ENRICH FAIL: %s email = %q, expected alice@example.com", enriched[i].EntryID, enriched[i].User.Email)
+		}
+	}
+	if enriched[2].User.Email != "bob@example.com" {
+		t.Errorf("FUNC-ENRICH FAIL: Existing email overwritten: %q", enriched[2].User.Email)
+	}
+	if entries[0].User.Email != "" {
+		t.Error("FUNC-ENRICH FAIL: Input entries were modified")
+	}
+	if n := mock.getRequestCount(); n != 1 {
+		t.Errorf("FUNC-ENRICH FAIL: Expected 1 GetUser call for alice, got %d: %v", n, mock.getRequestLog())
+	}
+
+	t.Logf("FUNC-ENRICH PASS: Filled missing email with %d lookup", mock.getRequestCount())
+}
+
+func TestFUNC_SyncReportsDeletedSchedulesAsMissing(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	mock.addSchedule("sched-002", "Secondary On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	opts := syncOptions{TreatNotFoundAsMissing: true}
+
+	// Deleted before the sync lists schedules
+	mock.removeSchedule("sched-002")
+	results, err := simulateFullSyncWithOptions(context.Background(), client, []string{"sched-001", "sched-002"}, opts)
+	if err != nil {
+		t.Fatalf("FUNC-SYNC-MISSING FAIL: %v", err)
+	}
+	if results[0].Missing || results[0].Error != nil || len(results[0].OnCallUsers) != 1 {
+		t.Errorf("FUNC-SYNC-MISSING FAIL: sched-001 should sync normally, got %+v", results[0])
+	}
+	if !results[1].Missing || results[1].Error != nil {
+		t.Fatalf("FUNC-SYNC-MISSING FAIL: Deleted schedule: expected Missing=true Error=nil, got Missing=%v Error=%v", results[1].Missing, results[1].Error)
+	}
+
+	// Deleted between listing and fetching entries
+	mock.failEndpoint("/v2/schedule_entries", 404)
+	results, err = simulateFullSyncWithOptions(context.Background(), client, []string{"sched-001"}, opts)
+	if err != nil {
+		t.Fatalf("FUNC-SYNC-MISSING FAIL: %v", err)
+	}
+	if !results[0].Missing || results[0].Error != nil {
+		t.Fatalf("FUNC-SYNC-MISSING FAIL: 404 on entries: expected Missing=true Error=nil, got Missing=%v Error=%v", results[0].Missing, results[0].Error)
+	}
+
+	// Without the option a deletion is still an error
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-002"})
+	if results[0].Missing || results[0].Error == nil {
+		t.Errorf("FUNC-SYNC-MISSING FAIL: Default sync should report an error, got %+v", results[0])
+	}
+
+	t.Log("FUNC-SYNC-MISSING PASS: Deleted schedules reported as Missing, other failures unchanged")
+}
+
+func TestFUNC_ListAllSchedulesUniqueCatchesDuplicates(t *testing.T) {
+	// Buggy backend: sched-001 appears on both pages
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := []map[string]interface{}{
+			{"id": "sched-001", "name": "Primary On-Call", "timezone": "UTC"},
+			{"id": "sched-002", "name": "Secondary On-Call", "timezone": "UTC"},
+		}
+		after := "page-2"
+		if r.URL.Query().Get("after") == "page-2" {
+			page = []map[string]interface{}{{"id": "sched-001", "name": "Primary On-Call", "timezone": "UTC"}}
+			after = ""
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules":       page,
+			"pagination_meta": map[string]interface{}{"after": after, "page_size": 2, "total_record_count": 3},
+		})
+	}))
+	defer srv.Close()
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+
+	all, err := listAllSchedules(context.Background(), client)
+	if err != nil {
+		t.Fatalf("FUNC-DUP-SCHEDULE FAIL: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("FUNC-DUP-SCHEDULE FAIL: Plain listing should pass the duplicate through, got %d schedules", len(all))
+	}
+
+	_, err = listAllSchedulesUnique(context.Background(), client)
+	if !errors.Is(err, errDuplicateSchedule) {
+		t.Fatalf("FUNC-DUP-SCHEDULE FAIL: Expected errDuplicateSchedule, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "sched-001") {
+		t.Errorf("FUNC-DUP-SCHEDULE FAIL: Error should name the duplicate ID: %v", err)
+	}
+
+	t.Logf("FUNC-DUP-SCHEDULE PASS: Duplicate caught: %v", err)
+}
+
+func TestFUNC_FilterEntriesByRole(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	mock.addUser("user-responder", "Rita Responder", "rita@example.com", "responder")
+	mock.addUser("user-observer", "Oscar Observer", "oscar@example.com", "observer")
+	mock.setOnCall("sched-001", []string{"user-responder", "user-observer"})
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	resp, err := client.ListScheduleEntriesWithContext(context.Background(), incidentio.ListScheduleEntriesOptions{ScheduleID: "sched-001"})
+	if err != nil {
+		t.Fatalf("FUNC-FILTER-ROLE FAIL: %v", err)
+	}
+	if len(resp.ScheduleEntries) != 2 {
+		t.Fatalf("FUNC-FILTER-ROLE FAIL: Expected 2 entries, got %d", len(resp.ScheduleEntries))
+	}
+
+	mock.resetRequestLog()
+	filtered, err := filterEntriesByRole(context.Background(), client, resp.ScheduleEntries, "responder")
+	if err != nil {
+		t.Fatalf("FUNC-FILTER-ROLE FAIL: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].User.ID != "user-responder" {
+		t.Fatalf("FUNC-FILTER-ROLE FAIL: Expected only the responder entry, got %+v", filtered)
+	}
+	if log := mock.getRequestLog(); len(log) != 1 || log[0] != "GET /v2/users" {
+		t.Errorf("FUNC-FILTER-ROLE FAIL: Expected a single ListUsers call, got %v", log)
+	}
+
+	t.Log("FUNC-FILTER-ROLE PASS: Observer dropped, responder kept, users resolved with one ListUsers call")
+}
+
+func TestFUNC_SlowRequestTransport(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.scenario().onPath("/v2/users").latency(100 * time.Millisecond).apply()
+
+	srv := mock.serve()
+	defer srv.Close()
+
+	var mu sync.Mutex
+	var slow []slowRequest
+	httpClient := &http.Client{Transport: slowRequestTransport(nil, 50*time.Millisecond, func(s slowRequest) {
+		mu.Lock()
+		defer mu.Unlock()
+		slow = append(slow, s)
+	})}
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL), incidentio.WithHTTPClient(httpClient))
+
+	// Under the threshold: no warning
+	if _, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{}); err != nil {
+		t.Fatalf("FUNC-SLOW-REQUEST FAIL: %v", err)
+	}
+	mu.Lock()
+	if len(slow) != 0 {
+		t.Errorf("FUNC-SLOW-REQUEST FAIL: Fast call reported as slow: %+v", slow)
+	}
+	mu.Unlock()
+
+	// Over the threshold: one warning naming the endpoint
+	if _, err := client.GetUserWithContext(context.Background(), "user-1", incidentio.GetUserOptions{}); err != nil {
+		t.Fatalf("FUNC-SLOW-REQUEST FAIL: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(slow) != 1 {
+		t.Fatalf("FUNC-SLOW-REQUEST FAIL: Expected 1 slow request, got %d", len(slow))
+	}
+	if slow[0].Endpoint != "GET /v2/users/user-1" || slow[0].Duration < 100*time.Millisecond {
+		t.Errorf("FUNC-SLOW-REQUEST FAIL: Unexpected event %+v", slow[0])
+	}
+
+	t.Logf("FUNC-SLOW-REQUEST PASS: %s reported after %v", slow[0].Endpoint, slow[0].Duration)
+}
+
+func TestFUNC_MockEscalationPaths(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addEscalationPath("esc-001", "Primary Escalation", []mockEscalationStep{
+		{Targets: []string{"sched-001"}, TimeToAckSeconds: 300},
+		{Targets: []string{"sched-002", "user-manager"}, TimeToAckSeconds: 600},
+	})
+	mock.addEscalationPath("esc-002", "Low Urgency", []mockEscalationStep{
+		{Targets: []string{"sched-002"}, TimeToAckSeconds: 1800},
+	})
+
+	srv := mock.serve()
+	defer srv.Close()
+
+	// The SDK has no escalation path methods yet
+	get := func(path string, v interface{}) int {
+		resp := rawGet(t, srv, path, nil)
+		defer resp.Body.Close()
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("FUNC-MOCK-ESCALATION FAIL: Decode %s: %v", path, err)
+		}
+		return resp.StatusCode
+	}
+
+	var list struct {
+		EscalationPaths []mockEscalationPath `json:"escalation_paths"`
+	}
+	get("/v2/escalation_paths", &list)
+	if len(list.EscalationPaths) != 2 || list.EscalationPaths[0].ID != "esc-001" || list.EscalationPaths[1].ID != "esc-002" {
+		t.Fatalf("FUNC-MOCK-ESCALATION FAIL: Expected esc-001, esc-002 in order, got %+v", list.EscalationPaths)
+	}
+
+	var single struct {
+		EscalationPath mockEscalationPath `json:"escalation_path"`
+	}
+	get("/v2/escalation_paths/esc-001", &single)
+	want, _ := mock.getEscalationPath("esc-001")
+	if !reflect.DeepEqual(single.EscalationPath, want) {
+		t.Fatalf("FUNC-MOCK-ESCALATION FAIL: Fields did not round-trip:\ngot  %+v\nwant %+v", single.EscalationPath, want)
+	}
+
+	var notFound map[string]interface{}
+	if status := get("/v2/escalation_paths/esc-missing", &notFound); status != 404 {
+		t.Errorf("FUNC-MOCK-ESCALATION FAIL: Expected 404 for unknown path, got %d", status)
+	}
+
+	t.Logf("FUNC-MOCK-ESCALATION PASS: %d escalation paths listed; %q has %d steps", len(list.EscalationPaths), single.EscalationPath.Name, len(single.EscalationPath.Steps))
+}
+
+func TestFUNC_ListOnCallUserIDs(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	var capturedStart string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedStart = r.URL.Query().Get("entry_window_start")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedule_entries": []map[string]interface{}{
+				{"entry_id": "e1", "user": map[string]interface{}{"id": "user-bob"}},
+				{"entry_id": "e2", "user": map[string]interface{}{"id": ""}},
+				{"entry_id": "e3", "user": map[string]interface{}{"id": "user-alice"}},
+				{"entry_id": "e4", "user": map[string]interface{}{"id": "user-bob"}},
+				{"entry_id": "e5"},
+			},
+			"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": 5},
+		})
+	}))
+	defer srv.Close()
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+
+	ids, err := listOnCallUserIDs(context.Background(), client, "sched-001", at)
+	if err != nil {
+		t.Fatalf("FUNC-ONCALL-IDS FAIL: %v", err)
+	}
+	if got := strings.Join(ids, ","); got != "user-bob,user-alice" {
+		t.Fatalf("FUNC-ONCALL-IDS FAIL: Expected unique non-empty IDs in first-seen order, got %q", got)
+	}
+	if capturedStart != "2026-03-01T09:30:00Z" {
+		t.Errorf("FUNC-ONCALL-IDS FAIL: Expected window to start at the requested instant, got %q", capturedStart)
+	}
+
+	t.Logf("FUNC-ONCALL-IDS PASS: 5 entries reduced to %v", ids)
+}
+
+func TestFUNC_SyncScheduleFilter(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Team Alpha", "UTC")
+	mock.addSchedule("sched-002", "Platform On-Call", "UTC")
+	mock.addSchedule("sched-003", "Team Beta", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	for _, id := range []string{"sched-001", "sched-002", "sched-003"} {
+		mock.setOnCall(id, []string{"user-1"})
+	}
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	results, err := simulateFullSyncWithOptions(context.Background(), client,
+		[]string{"sched-001", "sched-002", "sched-003"},
+		syncOptions{ScheduleFilter: func(s incidentio.Schedule) bool { return strings.HasPrefix(s.Name, "Team") }},
+	)
+	if err != nil {
+		t.Fatalf("FUNC-SYNC-FILTER FAIL: %v", err)
+	}
+
+	var synced []string
+	for _, r := range results {
+		if r.Error != nil {
+			t.Errorf("FUNC-SYNC-FILTER FAIL: %s: %v", r.ScheduleID, r.Error)
+		}
+		synced = append(synced, r.ScheduleID)
+	}
+	if got := strings.Join(synced, ","); got != "sched-001,sched-003" {
+		t.Fatalf("FUNC-SYNC-FILTER FAIL: Expected only Team schedules, got %s", got)
+	}
+	// Filtered schedules must not be fetched at all
+	if n := mock.getRequestCount(); n != 5 {
+		t.Errorf("FUNC-SYNC-FILTER FAIL: Expected 1 list + 2x(entries + user) = 5 requests, got %d: %v", n, mock.getRequestLog())
+	}
+
+	t.Logf("FUNC-SYNC-FILTER PASS: Synced %v, Platform On-Call excluded without error", synced)
+}
+
+func TestFUNC_ListAllUsersPaginates(t *testing.T) {
+	pages := map[string]struct {
+		ids   []string
+		after string
+	}{
+		"":       {[]string{"user-1", "user-2"}, "page-2"},
+		"page-2": {[]string{"user-3", "user-4"}, "page-3"},
+		"page-3": {[]string{"user-5"}, ""},
+		// Buggy backend: points back at a cursor it already returned
+		"loop": {[]string{"user-x"}, "loop"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := pages[r.URL.Query().Get("after")]
+		var users []map[string]interface{}
+		for _, id := range p.ids {
+			users = append(users, map[string]interface{}{"id": id, "name": id, "email": id + "@example.com"})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"users":           users,
+			"pagination_meta": map[string]interface{}{"after": p.after, "page_size": 2, "total_record_count": 5},
+		})
+	}))
+	defer srv.Close()
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
+
+	users, err := listAllUsers(context.Background(), client, incidentio.ListUsersOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("FUNC-LIST-USERS FAIL: %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, u := range users {
+		if seen[u.ID] {
+			t.Errorf("FUNC-LIST-USERS FAIL: Duplicate user %s", u.ID)
+		}
+		seen[u.ID] = true
+	}
+	if len(users) != 5 {
+		t.Fatalf("FUNC-LIST-USERS FAIL: Expected 5 users across 3 pages, got %d", len(users))
+	}
+
+	if _, err := listAllUsers(context.Background(), client, incidentio.ListUsersOptions{After: "loop"}); err == nil || !strings.Contains(err.Error(), "pagination loop") {
+		t.Errorf("FUNC-LIST-USERS FAIL: Repeated cursor should be detected, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := listAllUsers(ctx, client, incidentio.ListUsersOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("FUNC-LIST-USERS FAIL: Cancelled context should stop pagination, got %v", err)
+	}
+
+	t.Logf("FUNC-LIST-USERS PASS: %d users across 3 pages, loop and cancellation detected", len(users))
+}
+
+func TestFUNC_SyncResultTimestamps(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1"})
+	mock.scenario().onPath("/v2/schedule_entries").latency(20 * time.Millisecond).apply()
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	before := time.Now()
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-deleted"})
+	if err != nil {
+		t.Fatalf("FUNC-SYNC-TIMESTAMP FAIL: %v", err)
+	}
+
+	for _, r := range results {
+		if r.StartedAt.Before(before.Add(-time.Second)) || r.StartedAt.After(time.Now()) {
+			t.Errorf("FUNC-SYNC-TIMESTAMP FAIL: %s StartedAt %s is not recent", r.ScheduleID, r.StartedAt)
+		}
+		if r.Duration <= 0 {
+			t.Errorf("FUNC-SYNC-TIMESTAMP FAIL: %s Duration = %v, expected > 0", r.ScheduleID, r.Duration)
+		}
+	}
+	if results[0].Duration < 20*time.Millisecond {
+		t.Errorf("FUNC-SYNC-TIMESTAMP FAIL: Duration %v does not include the 20ms entries call", results[0].Duration)
+	}
+	if results[1].Error == nil {
+		t.Error("FUNC-SYNC-TIMESTAMP FAIL: Deleted schedule should still be an error")
+	}
+
+	t.Logf("FUNC-SYNC-TIMESTAMP PASS: sched-001 started %s, took %v", results[0].StartedAt.Format(time.RFC3339Nano), results[0].Duration)
+}
+
+func TestFUNC_SyncStopsAfterConsecutiveUserFailures(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	var onCall []string
+	for i := 0; i < 10; i++ {
+		userID := fmt.Sprintf("user-%02d", i)
+		mock.addUser(userID, "Deleted User", "", "responder")
+		onCall = append(onCall, userID)
+	}
+	mock.setOnCall("sched-001", onCall)
+	// Every user was deleted after the rota was built
+	mock.scenario().onPath("/v2/users/").fail(404).apply()
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	results, err := simulateFullSyncWithOptions(context.Background(), client, []string{"sched-001"},
+		syncOptions{MaxConsecutiveUserFailures: 3})
+	if err != nil {
+		t.Fatalf("FUNC-SYNC-USER-FAILURES FAIL: %v", err)
+	}
+
+	userCalls := 0
+	for _, entry := range mock.getRequestLog() {
+		if strings.HasPrefix(entry, "GET /v2/users/") {
+			userCalls++
+		}
+	}
+	if userCalls != 3 {
+		t.Errorf("FUNC-SYNC-USER-FAILURES FAIL: Expected resolution to stop after 3 GetUser calls, got %d", userCalls)
+	}
+	if !results[0].Partial || results[0].Error != nil {
+		t.Fatalf("FUNC-SYNC-USER-FAILURES FAIL: Expected Partial=true Error=nil, got Partial=%v Error=%v", results[0].Partial, results[0].Error)
+	}
+
+	// Without the threshold every user is still attempted
+	mock.resetRequestLog()
+	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if results[0].Partial || mock.getRequestCount() != 12 {
+		t.Errorf("FUNC-SYNC-USER-FAILURES FAIL: Default sync should try all 10 users (12 requests), got %d, Partial=%v", mock.getRequestCount(), results[0].Partial)
+	}
+
+	t.Logf("FUNC-SYNC-USER-FAILURES PASS: Stopped after %d consecutive 404s and marked the result partial", userCalls)
+}
+
+func TestFUNC_MockListUsersStablePagination(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	// Added out of order on purpose — map iteration must not leak into cursors
+	for _, id := range []string{"user-d", "user-a", "user-e", "user-c", "user-b"} {
+		mock.addUser(id, "User "+id, id+"@example.com", "responder")
+	}
+
+	srv := mock.serve()
+	defer srv.Close()
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+
+	users, err := listAllUsers(context.Background(), client, incidentio.ListUsersOptions{PageSize: 1})
+	if err != nil {
+		t.Fatalf("FUNC-MOCK-USERS-CURSOR FAIL: %v", err)
+	}
+
+	var ids []string
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	if got := strings.Join(ids, ","); got != "user-a,user-b,user-c,user-d,user-e" {
+		t.Fatalf("FUNC-MOCK-USERS-CURSOR FAIL: Expected each user once in sorted order, got %s", got)
+	}
+	if n := mock.getRequestCount(); n != 5 {
+		t.Errorf("FUNC-MOCK-USERS-CURSOR FAIL: Expected 5 pages of size 1, got %d requests", n)
+	}
+
+	t.Logf("FUNC-MOCK-USERS-CURSOR PASS: %d users across %d pages, each exactly once in sorted order", len(users), mock.getRequestCount())
+}
+
+func TestFUNC_MockScheduleIfModifiedSince(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	lastModified := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	mock.setScheduleLastModified("sched-001", lastModified)
+
+	srv := mock.serve()
+	defer srv.Close()
+
+	// The SDK can't send If-Modified-Since yet
+	get := func(since time.Time) *http.Response {
+		header := http.Header{}
+		if !since.IsZero() {
+			header.Set("If-Modified-Since", since.Format(http.TimeFormat))
+		}
+		resp := rawGet(t, srv, "/v2/schedules/sched-001", header)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := get(time.Time{})
+	if resp.StatusCode != 200 {
+		t.Fatalf("FUNC-MOCK-IMS FAIL: Plain GET expected 200, got %d", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Last-Modified"); got != lastModified.Format(http.TimeFormat) {
+		t.Errorf("FUNC-MOCK-IMS FAIL: Expected Last-Modified %q, got %q", lastModified.Format(http.TimeFormat), got)
+	}
+
+	if resp := get(lastModified); resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("FUNC-MOCK-IMS FAIL: Unchanged since last-seen time, expected 304, got %d", resp.StatusCode)
+	}
+	if resp := get(lastModified.Add(-time.Hour)); resp.StatusCode != 200 {
+		t.Errorf("FUNC-MOCK-IMS FAIL: Modified after last-seen time, expected 200, got %d", resp.StatusCode)
+	}
+
+	t.Log("FUNC-MOCK-IMS PASS: 304 when unchanged since the supplied time, 200 otherwise")
+}
+
+func TestFUNC_PreRequestTransport(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+
+	var mu sync.Mutex
+	var signatures []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		signatures = append(signatures, r.Header.Get("X-Proxy-Signature"))
+		mu.Unlock()
+		mock.handler().ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+	seen := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), signatures...)
+	}
+
+	newClient := func(hook func(*http.Request) error) *incidentio.Client {
+		return incidentio.NewClient("test-key",
+			incidentio.WithBaseURL(srv.URL),
+			incidentio.WithHTTPClient(&http.Client{Transport: preRequestTransport(nil, hook)}),
+		)
+	}
+
+	signed := newClient(func(r *http.Request) error {
+		r.Header.Set("X-Proxy-Signature", "sig:"+r.Method+":"+r.URL.Path)
+		return nil
+	})
+	if _, err := signed.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{}); err != nil {
+		t.Fatalf("FUNC-PRE-REQUEST FAIL: Signed request: %v", err)
+	}
+	if got := seen(); len(got) != 1 || got[0] != "sig:GET:/v2/schedules" {
+		t.Fatalf("FUNC-PRE-REQUEST FAIL: Server did not see the signature header: %v", got)
+	}
+
+	rejecting := newClient(func(r *http.Request) error { return errors.New("signing key unavailable") })
+	if _, err := rejecting.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{}); err == nil || !strings.Contains(err.Error(), "signing key unavailable") {
+		t.Errorf("FUNC-PRE-REQUEST FAIL: Hook error should abort the call, got %v", err)
+	}
+
+	stripping := newClient(func(r *http.Request) error {
+		r.Header.Del("Authorization")
+		return nil
+	})
+	if _, err := stripping.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{}); err == nil || !strings.Contains(err.Error(), "Authorization") {
+		t.Errorf("FUNC-PRE-REQUEST FAIL: Removing Authorization should fail loudly, got %v", err)
+	}
+
+	got := seen()
+	if len(got) != 1 {
+		t.Errorf("FUNC-PRE-REQUEST FAIL: Aborted calls must not reach the server, saw %d requests", len(got))
+	}
+
+	t.Logf("FUNC-PRE-REQUEST PASS: Server saw %q; hook errors and stripped auth aborted before sending", got[0])
+}
+
+func TestFUNC_BaseURLOverridePerCall(t *testing.T) {
+	primary := newMockIncidentIO("test-key")
+	primary.addSchedule("sched-us", "US On-Call", "America/New_York")
+	secondary := newMockIncidentIO("test-key")
+	secondary.addSchedule("sched-eu", "EU On-Call", "Europe/London")
+
+	primarySrv := primary.serve()
+	defer primarySrv.Close()
+	secondarySrv := secondary.serve()
+	defer secondarySrv.Close()
+
+	client := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(primarySrv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: baseURLOverrideTransport(nil)}),
+	)
+
+	ctx := withBaseURLOverride(context.Background(), secondarySrv.URL)
+	resp, err := client.ListSchedulesWithContext(ctx, incidentio.ListSchedulesOptions{})
+	if err != nil {
+		t.Fatalf("FUNC-BASEURL-OVERRIDE FAIL: Override call: %v", err)
+	}
+	if len(resp.Schedules) != 1 || resp.Schedules[0].ID != "sched-eu" {
+		t.Fatalf("FUNC-BASEURL-OVERRIDE FAIL: Expected sched-eu from the override server, got %+v", resp.Schedules)
+	}
+
+	resp, err = client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err != nil {
+		t.Fatalf("FUNC-BASEURL-OVERRIDE FAIL: Default call: %v", err)
+	}
+	if len(resp.Schedules) != 1 || resp.Schedules[0].ID != "sched-us" {
+		t.Fatalf("FUNC-BASEURL-OVERRIDE FAIL: Expected sched-us from the default server, got %+v", resp.Schedules)
 	}
 
-	t.Log("FUNC-CREATE PASS: Integration created and connection tested successfully")
+	if primary.getRequestCount() != 1 || secondary.getRequestCount() != 1 {
+		t.Errorf("FUNC-BASEURL-OVERRIDE FAIL: Expected one request per server, got primary=%d secondary=%d",
+			primary.getRequestCount(), secondary.getRequestCount())
+	}
+
+	t.Log("FUNC-BASEURL-OVERRIDE PASS: Per-call override reached the second server; default unchanged")
 }
 
-func TestFUNC_AddSchedulesSyncAndVerifyOnCall(t *testing.T) {
+func TestFUNC_SyncErrorAggregation(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "Platform On-Call", "America/New_York")
-	mock.addSchedule("sched-002", "Backend Primary", "America/Los_Angeles")
-	mock.addUser("user-alice", "Alice Chen", "alice@example.com", "responder")
-	mock.addUser("user-bob", "Bob Martinez", "bob@example.com", "responder")
-	mock.addUser("user-carol", "Carol Davis", "carol@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-alice", "user-bob"})
-	mock.setOnCall("sched-002", []string{"user-carol"})
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	mock.addSchedule("sched-002", "Secondary On-Call", "UTC")
+	mock.addSchedule("sched-003", "Tertiary On-Call", "UTC")
+	mock.failSchedule("sched-002", true)
 
 	srv := mock.serve()
 	defer srv.Close()
-
 	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	tracked := []string{"sched-001", "sched-002", "sched-003", "sched-deleted"}
 
-	// Step 1: List available schedules
-	schedules, err := listAllSchedules(context.Background(), client)
+	results, err := simulateFullSync(context.Background(), client, tracked)
 	if err != nil {
-		t.Fatalf("FUNC-SYNC FAIL: List schedules: %v", err)
+		t.Fatalf("FUNC-SYNC-ERROR FAIL: %v", err)
 	}
-	if len(schedules) != 2 {
-		t.Fatalf("FUNC-SYNC FAIL: Expected 2 schedules, got %d", len(schedules))
+	var agg *syncError
+	if !errors.As(syncErrors(results), &agg) {
+		t.Fatal("FUNC-SYNC-ERROR FAIL: Expected a *syncError for the failed schedules")
+	}
+	if len(agg.Errors()) != 2 || agg.Errors()["sched-002"] == nil || agg.Errors()["sched-deleted"] == nil {
+		t.Fatalf("FUNC-SYNC-ERROR FAIL: Expected errors for sched-002 and sched-deleted, got %v", agg.Errors())
+	}
+	if agg.Unwrap() != nil {
+		t.Errorf("FUNC-SYNC-ERROR FAIL: Partial failure should have no top-level cause, got %v", agg.Unwrap())
 	}
 
-	// Step 2: "Add" both schedules (track them)
-	tracked := []string{"sched-001", "sched-002"}
-
-	// Step 3: Run sync
-	results, err := simulateFullSync(context.Background(), client, tracked)
-	if err != nil {
-		t.Fatalf("FUNC-SYNC FAIL: Sync failed: %v", err)
+	// Whole-sync failure: the list-schedules error is the unwrappable cause
+	mock.failEndpoint("/v2/schedules", 503)
+	_, err = simulateFullSync(context.Background(), client, tracked)
+	if !errors.As(err, &agg) || agg.Cause == nil {
+		t.Fatalf("FUNC-SYNC-ERROR FAIL: Expected a *syncError with a cause, got %T %v", err, err)
+	}
+	var apiErr *incidentio.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 {
+		t.Errorf("FUNC-SYNC-ERROR FAIL: Expected the 503 APIError to unwrap from the aggregate, got %v", err)
 	}
 
-	// Step 4: Verify results
-	if len(results) != 2 {
-		t.Fatalf("FUNC-SYNC FAIL: Expected 2 results, got %d", len(results))
+	if syncErrors(results[:1]) != nil {
+		t.Error("FUNC-SYNC-ERROR FAIL: No failures should aggregate to nil")
 	}
 
-	for _, r := range results {
-		if r.Error != nil {
-			t.Fatalf("FUNC-SYNC FAIL: Schedule %s error: %v", r.ScheduleID, r.Error)
+	t.Logf("FUNC-SYNC-ERROR PASS: Per-schedule errors retrievable; outage cause unwraps: %v", err)
+}
+
+func TestFUNC_MockListSchedulesIncludeArchived(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	mock.addSchedule("sched-002", "Old Rotation", "UTC")
+	mock.archiveSchedule("sched-002", true)
+
+	srv := mock.serve()
+	defer srv.Close()
+
+	// The SDK has no IncludeArchived option yet
+	list := func(query string) []string {
+		resp := rawGet(t, srv, "/v2/schedules"+query, nil)
+		defer resp.Body.Close()
+		var body struct {
+			Schedules []mockSchedule `json:"schedules"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("FUNC-MOCK-ARCHIVED FAIL: %v", err)
+		}
+		var ids []string
+		for _, s := range body.Schedules {
+			ids = append(ids, s.ID)
 		}
+		return ids
 	}
 
-	// Verify sched-001 has alice and bob
-	r1 := results[0]
-	if r1.ScheduleID == "sched-002" {
-		r1 = results[1]
+	if got := strings.Join(list(""), ","); got != "sched-001" {
+		t.Errorf("FUNC-MOCK-ARCHIVED FAIL: Archived schedule listed by default: %s", got)
 	}
-	if len(r1.OnCallUsers) != 2 {
-		t.Fatalf("FUNC-SYNC FAIL: sched-001 should have 2 on-call users, got %d", len(r1.OnCallUsers))
+	if got := strings.Join(list("?include_archived=true"), ","); got != "sched-001,sched-002" {
+		t.Errorf("FUNC-MOCK-ARCHIVED FAIL: Expected both schedules with include_archived, got %s", got)
 	}
 
-	// Verify sched-002 has carol
-	r2 := results[1]
-	if r2.ScheduleID == "sched-001" {
-		r2 = results[0]
-	}
-	if len(r2.OnCallUsers) != 1 {
-		t.Fatalf("FUNC-SYNC FAIL: sched-002 should have 1 on-call user, got %d", len(r2.OnCallUsers))
+	mock.archiveSchedule("sched-002", false)
+	if got := strings.Join(list(""), ","); got != "sched-001,sched-002" {
+		t.Errorf("FUNC-MOCK-ARCHIVED FAIL: Unarchived schedule should be listed again, got %s", got)
 	}
 
-	t.Logf("FUNC-SYNC PASS: Synced %d schedules, resolved %d + %d on-call users",
-		len(results), len(r1.OnCallUsers), len(r2.OnCallUsers))
+	t.Log("FUNC-MOCK-ARCHIVED PASS: Archived schedules listed only with include_archived=true")
 }
 
-func TestFUNC_RemoveScheduleAndVerifyCleanup(t *testing.T) {
+func TestFUNC_SnapshotOnCallSharesUserIndex(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "Platform On-Call", "UTC")
-	mock.addSchedule("sched-002", "Backend Primary", "UTC")
 	mock.addUser("user-alice", "Alice", "alice@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-alice"})
-	mock.setOnCall("sched-002", []string{"user-alice"})
+	mock.addUser("user-bob", "Bob", "bob@example.com", "responder")
+	for _, id := range []string{"sched-001", "sched-002", "sched-003"} {
+		mock.addSchedule(id, "Schedule "+id, "UTC")
+		mock.setOnCall(id, []string{"user-alice"})
+	}
+	mock.setOnCall("sched-003", []string{"user-alice", "user-bob"})
 
 	srv := mock.serve()
 	defer srv.Close()
 	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	// Sync with both schedules
-	results, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-002"})
+	snapshot, err := snapshotOnCall(context.Background(), client, []string{"sched-001", "sched-002", "sched-003"})
 	if err != nil {
-		t.Fatalf("FUNC-REMOVE FAIL: Initial sync: %v", err)
+		t.Fatalf("FUNC-SNAPSHOT FAIL: %v", err)
 	}
-	if len(results) != 2 {
-		t.Fatalf("FUNC-REMOVE FAIL: Expected 2 results initially, got %d", len(results))
+	for _, id := range []string{"sched-001", "sched-002"} {
+		if len(snapshot[id]) != 1 || snapshot[id][0].Email != "alice@example.com" {
+			t.Errorf("FUNC-SNAPSHOT FAIL: %s: expected alice, got %+v", id, snapshot[id])
+		}
+	}
+	if len(snapshot["sched-003"]) != 2 {
+		t.Errorf("FUNC-SNAPSHOT FAIL: sched-003: expected alice and bob, got %+v", snapshot["sched-003"])
 	}
 
-	// Remove sched-002 from tracking
-	results, err = simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if err != nil {
-		t.Fatalf("FUNC-REMOVE FAIL: Post-remove sync: %v", err)
+	userLookups := 0
+	for _, entry := range mock.getRequestLog() {
+		if strings.HasPrefix(entry, "GET /v2/users") {
+			userLookups++
+		}
 	}
-	if len(results) != 1 {
-		t.Fatalf("FUNC-REMOVE FAIL: Expected 1 result after removal, got %d", len(results))
+	if userLookups != 1 {
+		t.Errorf("FUNC-SNAPSHOT FAIL: Expected 1 user lookup for the whole snapshot, got %d: %v", userLookups, mock.getRequestLog())
 	}
-	if results[0].ScheduleID != "sched-001" {
-		t.Fatalf("FUNC-REMOVE FAIL: Expected sched-001, got %s", results[0].ScheduleID)
+
+	mock.failSchedule("sched-002", true)
+	if _, err := snapshotOnCall(context.Background(), client, []string{"sched-001", "sched-002"}); err == nil || !strings.Contains(err.Error(), "sched-002") {
+		t.Errorf("FUNC-SNAPSHOT FAIL: Expected the sched-002 failure to fail the snapshot, got %v", err)
 	}
 
-	t.Log("FUNC-REMOVE PASS: Schedule removed, only tracked schedule synced")
+	t.Logf("FUNC-SNAPSHOT PASS: Alice on 3 schedules resolved with %d user lookup", userLookups)
 }
 
-func TestFUNC_FullSyncLifecycle(t *testing.T) {
-	mock := newMockIncidentIO("lifecycle-key")
+func TestFUNC_MockFailListPageRecoversOnRetry(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	for i := 1; i <= 6; i++ {
+		mock.addSchedule(fmt.Sprintf("sched-%03d", i), fmt.Sprintf("Schedule %d", i), "UTC")
+	}
+	// Page 2 is rate limited once; the SDK retries 429s
+	mock.failListPage("schedules", 2, 429)
+
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("lifecycle-key", incidentio.WithBaseURL(srv.URL))
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	// Phase 1: Empty state — no schedules exist
-	schedules, err := listAllSchedules(context.Background(), client)
-	if err != nil {
-		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 1 list: %v", err)
+	var ids []string
+	opts := incidentio.ListSchedulesOptions{PageSize: 2}
+	for page := 0; page < 10; page++ {
+		resp, err := client.ListSchedulesWithContext(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("FUNC-MOCK-PAGE-FAIL FAIL: Page %d: %v", page+1, err)
+		}
+		for _, s := range resp.Schedules {
+			ids = append(ids, s.ID)
+		}
+		if resp.PaginationMeta.After == "" {
+			break
+		}
+		opts.After = resp.PaginationMeta.After
 	}
-	if len(schedules) != 0 {
-		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 1 expected 0 schedules, got %d", len(schedules))
+
+	if got := strings.Join(ids, ","); got != "sched-001,sched-002,sched-003,sched-004,sched-005,sched-006" {
+		t.Fatalf("FUNC-MOCK-PAGE-FAIL FAIL: Expected the full list, got %s", got)
+	}
+	if n := mock.getRequestCount(); n != 4 {
+		t.Errorf("FUNC-MOCK-PAGE-FAIL FAIL: Expected 3 pages + 1 retry = 4 requests, got %d", n)
 	}
 
-	// Phase 2: Admin creates schedules in incident.io
-	mock.addSchedule("sched-A", "Team Alpha", "UTC")
-	mock.addSchedule("sched-B", "Team Beta", "America/Chicago")
-	mock.addUser("user-1", "User One", "one@example.com", "responder")
-	mock.addUser("user-2", "User Two", "two@example.com", "responder")
-	mock.setOnCall("sched-A", []string{"user-1"})
-	mock.setOnCall("sched-B", []string{"user-2"})
+	t.Logf("FUNC-MOCK-PAGE-FAIL PASS: Page 2 failed once, retried, and the list completed in %d requests", mock.getRequestCount())
+}
 
-	schedules, _ = listAllSchedules(context.Background(), client)
-	if len(schedules) != 2 {
-		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 2 expected 2 schedules, got %d", len(schedules))
-	}
+func TestFUNC_ListAllSchedulesMaxPagesExceeded(t *testing.T) {
+	// Backend that always has another page
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules":       []map[string]interface{}{{"id": fmt.Sprintf("sched-%04d", n), "name": "Schedule", "timezone": "UTC"}},
+			"pagination_meta": map[string]interface{}{"after": fmt.Sprintf("cursor-%d", n), "page_size": 1, "total_record_count": 1000000},
+		})
+	}))
+	defer srv.Close()
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
 
-	// Phase 3: Track both schedules and sync
-	results, _ := simulateFullSync(context.Background(), client, []string{"sched-A", "sched-B"})
-	if len(results) != 2 {
-		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 3 expected 2 results, got %d", len(results))
+	schedules, err := listAllSchedules(context.Background(), client)
+	if !errors.Is(err, errMaxPagesExceeded) {
+		t.Fatalf("FUNC-MAX-PAGES FAIL: Expected errMaxPagesExceeded, got err=%v with %d schedules", err, len(schedules))
 	}
-	for _, r := range results {
-		if r.Error != nil {
-			t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 3 error: %v", r.Error)
-		}
-		if len(r.OnCallUsers) != 1 {
-			t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 3 expected 1 user per schedule, got %d for %s", len(r.OnCallUsers), r.ScheduleID)
-		}
+	if schedules != nil {
+		t.Errorf("FUNC-MAX-PAGES FAIL: Expected no partial list alongside the error, got %d schedules", len(schedules))
 	}
-
-	// Phase 4: On-call rotation — user-1 goes off, user-2 takes over both
-	mock.setOnCall("sched-A", []string{"user-2"})
-	results, _ = simulateFullSync(context.Background(), client, []string{"sched-A", "sched-B"})
-	for _, r := range results {
-		if r.ScheduleID == "sched-A" {
-			if len(r.OnCallUsers) != 1 || r.OnCallUsers[0].UserID != "user-2" {
-				t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 4 sched-A should have user-2, got %v", r.OnCallUsers)
-			}
-		}
+	if n := atomic.LoadInt32(&requests); n != maxListPages {
+		t.Errorf("FUNC-MAX-PAGES FAIL: Expected %d page requests, got %d", maxListPages, n)
 	}
 
-	// Phase 5: Schedule renamed in incident.io
-	mock.renameSchedule("sched-A", "Team Alpha (Renamed)")
-	sched, err := client.GetScheduleWithContext(context.Background(), "sched-A", incidentio.GetScheduleOptions{})
-	if err != nil {
-		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 5 get schedule: %v", err)
+	t.Logf("FUNC-MAX-PAGES PASS: Stopped after %d pages with: %v", maxListPages, err)
+}
+
+func TestFUNC_DefaultContextTimeoutAbortsHangingCall(t *testing.T) {
+	// Server that never answers until the client gives up
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	const defaultTimeout = 200 * time.Millisecond
+	client := incidentio.NewClient(validAPIKey,
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: defaultContextTimeoutTransport(nil, defaultTimeout)}),
+	)
+
+	start := time.Now()
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	elapsed := time.Since(start)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("FUNC-CTX-DEFAULT-TIMEOUT FAIL: Expected context.DeadlineExceeded, got %v", err)
 	}
-	if sched.Name != "Team Alpha (Renamed)" {
-		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 5 expected renamed schedule, got %q", sched.Name)
+	if elapsed < defaultTimeout || elapsed > 5*defaultTimeout {
+		t.Errorf("FUNC-CTX-DEFAULT-TIMEOUT FAIL: Expected abort at ~%v, took %v", defaultTimeout, elapsed)
 	}
 
-	// Phase 6: Schedule deleted in incident.io
-	mock.removeSchedule("sched-B")
-	results, _ = simulateFullSync(context.Background(), client, []string{"sched-A", "sched-B"})
-	for _, r := range results {
-		if r.ScheduleID == "sched-B" && r.Error == nil {
-			t.Fatal("FUNC-LIFECYCLE FAIL: Phase 6 deleted schedule should have error")
-		}
+	// A caller's shorter deadline still wins
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	_, err = client.ListSchedulesWithContext(ctx, incidentio.ListSchedulesOptions{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("FUNC-CTX-DEFAULT-TIMEOUT FAIL: Expected caller deadline to fire, got %v", err)
+	}
+	if d := time.Since(start); d >= defaultTimeout {
+		t.Errorf("FUNC-CTX-DEFAULT-TIMEOUT FAIL: Caller deadline was overridden, took %v", d)
 	}
 
-	// Phase 7: New schedule added
-	mock.addSchedule("sched-C", "Team Charlie", "Europe/London")
-	mock.addUser("user-3", "User Three", "three@example.com", "responder")
-	mock.setOnCall("sched-C", []string{"user-3"})
+	t.Logf("FUNC-CTX-DEFAULT-TIMEOUT PASS: Deadline-less call aborted after %v", elapsed.Round(time.Millisecond))
+}
 
-	results, _ = simulateFullSync(context.Background(), client, []string{"sched-A", "sched-C"})
-	if len(results) != 2 {
-		t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 7 expected 2 results, got %d", len(results))
+func TestFUNC_CrossHostRedirectDoesNotLeakAuth(t *testing.T) {
+	// Downstream host that should never see the API key
+	downstream := newMockIncidentIO("test-key")
+	dsrv := downstream.serve()
+	defer dsrv.Close()
+	// Redirect to the same server under another hostname, which net/http
+	// treats as a different host for sensitive-header forwarding
+	target := strings.Replace(dsrv.URL, "127.0.0.1", "localhost", 1)
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target+r.URL.RequestURI(), http.StatusFound)
+	}))
+	defer upstream.Close()
+
+	clients := map[string]*incidentio.Client{
+		"default":          incidentio.NewClient("test-key", incidentio.WithBaseURL(upstream.URL)),
+		"following client": incidentio.NewClient("test-key", incidentio.WithBaseURL(upstream.URL), incidentio.WithHTTPClient(&http.Client{})),
 	}
-	for _, r := range results {
-		if r.Error != nil {
-			t.Fatalf("FUNC-LIFECYCLE FAIL: Phase 7 error on %s: %v", r.ScheduleID, r.Error)
+	for name, client := range clients {
+		downstream.resetRequestLog()
+		client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+		if downstream.getRequestCount() == 0 {
+			t.Logf("FUNC-REDIRECT-AUTH: %s client did not follow the redirect", name)
+			continue
+		}
+		if auth := downstream.lastAuthSeen(); auth != "" {
+			t.Errorf("FUNC-REDIRECT-AUTH FAIL: %s client sent Authorization %q to the redirect target", name, auth)
 		}
 	}
 
-	t.Log("FUNC-LIFECYCLE PASS: Full lifecycle tested — create, sync, rotate, rename, delete, re-add")
+	t.Log("FUNC-REDIRECT-AUTH PASS: Redirect target never saw the API key")
 }
 
-func TestFUNC_UserResolutionByEmail(t *testing.T) {
-	mock := newMockIncidentIO("email-key")
-	mock.addSchedule("sched-001", "On-Call", "UTC")
-	mock.addUser("user-alice", "Alice Chen", "alice@strongdm.com", "responder")
-	mock.addUser("user-bob", "Bob Martinez", "bob@strongdm.com", "responder")
-	mock.addUser("user-noemail", "No Email User", "", "observer")
-	mock.setOnCall("sched-001", []string{"user-alice", "user-bob", "user-noemail"})
-
+func TestFUNC_NextHandoffLocal(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-nyc", "NYC On-Call", "America/New_York")
+	mock.addSchedule("sched-empty", "Unstaffed", "America/New_York")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.setOnCall("sched-nyc", []string{"user-1"})
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("email-key", incidentio.WithBaseURL(srv.URL))
 
-	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	at := time.Now()
+	handoff, zone, err := nextHandoffLocal(context.Background(), client, "sched-nyc", at)
 	if err != nil {
-		t.Fatalf("FUNC-EMAIL FAIL: %v", err)
+		t.Fatalf("FUNC-HANDOFF-LOCAL FAIL: %v", err)
 	}
-
-	r := results[0]
-	if r.Error != nil {
-		t.Fatalf("FUNC-EMAIL FAIL: %v", r.Error)
+	if zone != "America/New_York" || handoff.Location().String() != "America/New_York" {
+		t.Errorf("FUNC-HANDOFF-LOCAL FAIL: Expected America/New_York, got zone %q and location %q", zone, handoff.Location())
 	}
-
-	// Should resolve 3 users (including empty email one — SDK returns it, integration layer would filter)
-	emailCount := 0
-	noEmailCount := 0
-	for _, u := range r.OnCallUsers {
-		if u.Email != "" {
-			emailCount++
-		} else {
-			noEmailCount++
-		}
+	// Mock shifts end 7h from when the entries were served
+	if d := handoff.Sub(at); d < 7*time.Hour-time.Minute || d > 7*time.Hour+time.Minute {
+		t.Errorf("FUNC-HANDOFF-LOCAL FAIL: Expected handoff ~7h out, got %v", d)
 	}
 
-	t.Logf("FUNC-EMAIL PASS: Resolved %d users with email, %d without email", emailCount, noEmailCount)
-	if noEmailCount > 0 {
-		t.Log("FUNC-EMAIL FINDING: Users without email are returned by SDK. Integration layer must filter for EMAIL sync mode.")
+	if _, _, err := nextHandoffLocal(context.Background(), client, "sched-empty", at); err == nil {
+		t.Error("FUNC-HANDOFF-LOCAL FAIL: Schedule with nobody on call should error")
 	}
-}
-
-// ============================================================================
-// FUNCTIONAL TESTS — Edge Cases
-// ============================================================================
 
-func TestFUNC_DisconnectReconnect(t *testing.T) {
-	mock := newMockIncidentIO("valid-key")
-	mock.addSchedule("sched-001", "On-Call", "UTC")
-	mock.addUser("user-1", "User One", "one@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-1"})
+	t.Logf("FUNC-HANDOFF-LOCAL PASS: Next handoff %s (%s)", handoff.Format(time.RFC3339), zone)
+}
 
+func TestFUNC_StreamSchedules(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	for i := 0; i < 60; i++ {
+		mock.addSchedule(fmt.Sprintf("sched-%03d", i), fmt.Sprintf("Schedule %d", i), "UTC")
+	}
 	srv := mock.serve()
 	defer srv.Close()
 
-	// Step 1: Connect with valid key — works
-	client := incidentio.NewClient("valid-key", incidentio.WithBaseURL(srv.URL))
-	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if err != nil || results[0].Error != nil {
-		t.Fatalf("FUNC-DISCONNECT FAIL: Initial sync should work: %v", err)
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	schedules, errc := streamSchedules(context.Background(), client, incidentio.ListSchedulesOptions{PageSize: 25})
+	seen := make(map[string]bool)
+	for s := range schedules {
+		seen[s.ID] = true
 	}
-
-	// Step 2: "Revoke" API key — simulate by using wrong key
-	badClient := incidentio.NewClient("revoked-key", incidentio.WithBaseURL(srv.URL))
-	_, err = simulateFullSync(context.Background(), badClient, []string{"sched-001"})
-	if err == nil {
-		t.Fatal("FUNC-DISCONNECT FAIL: Revoked key should fail")
+	if err := <-errc; err != nil {
+		t.Fatalf("FUNC-STREAM FAIL: Stream ended with error: %v", err)
 	}
-
-	// Step 3: "Reconnect" with new valid key — works again
-	newClient := incidentio.NewClient("valid-key", incidentio.WithBaseURL(srv.URL))
-	results, err = simulateFullSync(context.Background(), newClient, []string{"sched-001"})
-	if err != nil || results[0].Error != nil {
-		t.Fatalf("FUNC-DISCONNECT FAIL: Reconnect should work: %v", err)
+	if len(seen) != 60 {
+		t.Errorf("FUNC-STREAM FAIL: Expected 60 schedules, got %d", len(seen))
+	}
+	if got := mock.getRequestCount(); got != 3 {
+		t.Errorf("FUNC-STREAM FAIL: Expected 3 page requests, got %d", got)
 	}
 
-	t.Log("FUNC-DISCONNECT PASS: Disconnect and reconnect cycle works correctly")
-}
+	// A failing list surfaces on the error channel and closes the stream
+	mock.failEndpoint("/v2/schedules", 500)
+	schedules, errc = streamSchedules(context.Background(), client, incidentio.ListSchedulesOptions{PageSize: 25})
+	for range schedules {
+		t.Error("FUNC-STREAM FAIL: No schedules expected from a failing list")
+	}
+	if err := <-errc; err == nil {
+		t.Error("FUNC-STREAM FAIL: Expected an error from a failing list")
+	}
 
-func TestFUNC_ScheduleDeletedDuringSync(t *testing.T) {
-	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "Stable Schedule", "UTC")
-	mock.addSchedule("sched-ephemeral", "Ephemeral Schedule", "UTC")
-	mock.addUser("user-1", "User One", "one@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-1"})
-	mock.setOnCall("sched-ephemeral", []string{"user-1"})
+	t.Logf("FUNC-STREAM PASS: Streamed %d schedules over 3 pages and closed cleanly", len(seen))
+}
 
-	srv := mock.serve()
+func TestFUNC_ListAllSchedulesCountVerification(t *testing.T) {
+	// Backend whose reported total disagrees with what it returns
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules": []map[string]interface{}{
+				{"id": "sched-001", "name": "One", "timezone": "UTC"},
+				{"id": "sched-002", "name": "Two", "timezone": "UTC"},
+				{"id": "sched-003", "name": "Three", "timezone": "UTC"},
+			},
+			"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": 999},
+		})
+	}))
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
 
-	// Initial sync works with both
-	results, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-ephemeral"})
-	if err != nil {
-		t.Fatalf("FUNC-DELETED-DURING FAIL: Initial sync: %v", err)
+	if schedules, err := listAllSchedules(context.Background(), client); err != nil || len(schedules) != 3 {
+		t.Fatalf("FUNC-COUNT-VERIFY FAIL: Unverified list should return 3 schedules, got %d (err=%v)", len(schedules), err)
 	}
-	if len(results) != 2 {
-		t.Fatalf("FUNC-DELETED-DURING FAIL: Expected 2 results, got %d", len(results))
+	schedules, err := listAllSchedulesWithOptions(context.Background(), client, listAllOptions{VerifyCount: true})
+	if !errors.Is(err, errCountMismatch) {
+		t.Fatalf("FUNC-COUNT-VERIFY FAIL: Expected errCountMismatch, got err=%v with %d schedules", err, len(schedules))
 	}
 
-	// Delete schedule between syncs
-	mock.removeSchedule("sched-ephemeral")
-
-	// Next sync should handle deleted schedule gracefully
-	results, err = simulateFullSync(context.Background(), client, []string{"sched-001", "sched-ephemeral"})
-	if err != nil {
-		t.Fatalf("FUNC-DELETED-DURING FAIL: Post-delete sync should not fail entirely: %v", err)
+	// Consistent paginated counts pass verification
+	mock := newMockIncidentIO("test-key")
+	for i := 0; i < 300; i++ {
+		mock.addSchedule(fmt.Sprintf("sched-%03d", i), fmt.Sprintf("Schedule %d", i), "UTC")
+	}
+	msrv := mock.serve()
+	defer msrv.Close()
+	mockClient := incidentio.NewClient("test-key", incidentio.WithBaseURL(msrv.URL))
+	if _, err := listAllSchedulesWithOptions(context.Background(), mockClient, listAllOptions{VerifyCount: true}); err != nil {
+		t.Errorf("FUNC-COUNT-VERIFY FAIL: Consistent counts should verify: %v", err)
 	}
 
-	// sched-001 should still sync fine
-	// sched-ephemeral should have an error
-	var stableOK, ephemeralError bool
-	for _, r := range results {
-		if r.ScheduleID == "sched-001" && r.Error == nil {
-			stableOK = true
+	t.Logf("FUNC-COUNT-VERIFY PASS: %v", err)
+}
+
+func TestFUNC_RequireHTTPSTransport(t *testing.T) {
+	// Stands in for the network so https://example.com never leaves the process
+	var sent []string
+	fake := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		sent = append(sent, r.URL.String())
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"schedules": [], "pagination_meta": {"after": "", "page_size": 250, "total_record_count": 0}}`)),
+			Request:    r,
+		}, nil
+	})
+
+	for _, tc := range []struct {
+		baseURL string
+		allowed bool
+	}{
+		{"http://example.com", false},
+		{"https://example.com", true},
+		{"http://127.0.0.1:8080", true},
+		{"http://localhost:8080", true},
+		{"http://[::1]:8080", true},
+	} {
+		client := incidentio.NewClient(validAPIKey,
+			incidentio.WithBaseURL(tc.baseURL),
+			incidentio.WithHTTPClient(&http.Client{Transport: requireHTTPSTransport(fake)}),
+		)
+		sent = nil
+		_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+		if tc.allowed && err != nil {
+			t.Errorf("FUNC-REQUIRE-HTTPS FAIL: %s should be allowed: %v", tc.baseURL, err)
 		}
-		if r.ScheduleID == "sched-ephemeral" && r.Error != nil {
-			ephemeralError = true
+		if !tc.allowed && (err == nil || len(sent) != 0) {
+			t.Errorf("FUNC-REQUIRE-HTTPS FAIL: %s should be rejected before sending (err=%v, sent=%v)", tc.baseURL, err, sent)
 		}
 	}
 
-	if !stableOK {
-		t.Fatal("FUNC-DELETED-DURING FAIL: Stable schedule should still sync")
-	}
-	if !ephemeralError {
-		t.Fatal("FUNC-DELETED-DURING FAIL: Deleted schedule should report error")
-	}
-
-	t.Log("FUNC-DELETED-DURING PASS: Deleted schedule handled gracefully, stable schedule still syncs")
+	t.Log("FUNC-REQUIRE-HTTPS PASS: Plain http rejected except for loopback hosts")
 }
 
-func TestFUNC_OnCallRotationBetweenSyncs(t *testing.T) {
+func TestFUNC_StatsTransportReset(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "On-Call", "UTC")
-	mock.addUser("user-day", "Day Person", "day@example.com", "responder")
-	mock.addUser("user-night", "Night Person", "night@example.com", "responder")
-
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.failSchedule("sched-002", true)
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	// Sync 1: Day shift
-	mock.setOnCall("sched-001", []string{"user-day"})
-	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if results[0].OnCallUsers[0].UserID != "user-day" {
-		t.Fatalf("FUNC-ROTATION FAIL: Sync 1 should show day user, got %s", results[0].OnCallUsers[0].UserID)
+	stats := newStatsTransport(nil)
+	client := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: stats}),
+	)
+
+	client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	client.ListScheduleEntriesWithContext(context.Background(), incidentio.ListScheduleEntriesOptions{ScheduleID: "sched-002"})
+	want := transportStats{Requests: 2, Status5xx: 1}
+	if got := stats.resetStats(); got != want {
+		t.Fatalf("FUNC-STATS-RESET FAIL: Expected %+v, got %+v", want, got)
 	}
-
-	// Sync 2: Night shift — rotation happened
-	mock.setOnCall("sched-001", []string{"user-night"})
-	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if results[0].OnCallUsers[0].UserID != "user-night" {
-		t.Fatalf("FUNC-ROTATION FAIL: Sync 2 should show night user, got %s", results[0].OnCallUsers[0].UserID)
+	if got := stats.stats(); got != (transportStats{}) {
+		t.Fatalf("FUNC-STATS-RESET FAIL: Expected all counters zero after reset, got %+v", got)
 	}
 
-	// Sync 3: Handoff — both on call during overlap
-	mock.setOnCall("sched-001", []string{"user-day", "user-night"})
-	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if len(results[0].OnCallUsers) != 2 {
-		t.Fatalf("FUNC-ROTATION FAIL: Sync 3 should show 2 users, got %d", len(results[0].OnCallUsers))
+	// Reset while requests are in flight: every request lands in exactly one cycle
+	var wg sync.WaitGroup
+	total := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+		}()
+		if i%5 == 0 {
+			total += stats.resetStats().Requests
+		}
+	}
+	wg.Wait()
+	total += stats.resetStats().Requests
+	if total != 20 {
+		t.Errorf("FUNC-STATS-RESET FAIL: Expected 20 requests across resets, counted %d", total)
+	}
+	if got := stats.stats(); got != (transportStats{}) {
+		t.Errorf("FUNC-STATS-RESET FAIL: Expected all counters zero after reset, got %+v", got)
 	}
 
-	t.Log("FUNC-ROTATION PASS: On-call rotation correctly reflected across syncs")
+	t.Log("FUNC-STATS-RESET PASS: Counters zeroed atomically with no request lost across resets")
 }
 
-func TestFUNC_EmptyScheduleNoOneOnCall(t *testing.T) {
+func TestFUNC_SyncFlagsPaginatedEntries(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "On-Call", "UTC")
-	mock.addUser("user-1", "User One", "one@example.com", "responder")
-
+	mock.addSchedule("sched-big", "Big Schedule", "UTC")
+	var userIDs []string
+	for i := 0; i < 300; i++ {
+		id := fmt.Sprintf("user-%03d", i)
+		mock.addUser(id, fmt.Sprintf("User %d", i), fmt.Sprintf("user%d@example.com", i), "responder")
+		userIDs = append(userIDs, id)
+	}
+	mock.setOnCall("sched-big", userIDs)
+	mock.setEntryPageSize(100)
 	srv := mock.serve()
 	defer srv.Close()
 	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	// Initially someone is on-call
-	mock.setOnCall("sched-001", []string{"user-1"})
-	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if len(results[0].OnCallUsers) != 1 {
-		t.Fatalf("FUNC-EMPTY FAIL: Should have 1 user initially")
+	var warnings []syncWarning
+	results, err := simulateFullSyncWithOptions(context.Background(), client, []string{"sched-big"}, syncOptions{
+		OnWarning: func(w syncWarning) { warnings = append(warnings, w) },
+	})
+	if err != nil {
+		t.Fatalf("FUNC-ENTRY-PAGES FAIL: %v", err)
+	}
+	if !results[0].Partial {
+		t.Errorf("FUNC-ENTRY-PAGES FAIL: Sync with entries left on later pages should be Partial")
+	}
+	want := syncWarning{ScheduleID: "sched-big", Reason: syncWarningEntriesTruncated}
+	if len(warnings) != 1 || warnings[0] != want {
+		t.Errorf("FUNC-ENTRY-PAGES FAIL: Expected warning %+v, got %+v", want, warnings)
+	}
+	if got := len(results[0].OnCallUsers); got < 300 {
+		t.Logf("FUNC-ENTRY-PAGES FINDING: %d of 300 on-call users resolved; ListScheduleEntriesOptions has no After cursor to fetch the rest", got)
 	}
 
-	// Shift gap — no one on-call
-	mock.clearOnCall("sched-001")
-	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if len(results[0].OnCallUsers) != 0 {
-		t.Fatalf("FUNC-EMPTY FAIL: Should have 0 users during gap")
+	page, err := client.ListScheduleEntriesWithContext(context.Background(), incidentio.ListScheduleEntriesOptions{ScheduleID: "sched-big"})
+	if err != nil {
+		t.Fatalf("FUNC-ENTRY-PAGES FAIL: %v", err)
+	}
+	if page.PaginationMeta.PageSize != 100 || len(page.ScheduleEntries) != 100 {
+		t.Errorf("FUNC-ENTRY-PAGES FAIL: Expected a 100-entry page reporting page_size 100, got %d entries with page_size %d",
+			len(page.ScheduleEntries), page.PaginationMeta.PageSize)
 	}
 
-	t.Log("FUNC-EMPTY PASS: Empty on-call set handled (0 users returned)")
-	t.Log("FUNC-EMPTY FINDING: Integration layer (SYNC-009 fix) should preserve previous members here")
+	t.Log("FUNC-ENTRY-PAGES PASS: Entries spanning several pages are reported as a partial sync")
 }
 
-func TestFUNC_SameUserAcrossMultipleSchedules(t *testing.T) {
+func TestFUNC_MisspelledSchedulesKey(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
 	mock.addSchedule("sched-001", "Primary", "UTC")
-	mock.addSchedule("sched-002", "Escalation", "UTC")
-	mock.addUser("user-alice", "Alice", "alice@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-alice"})
-	mock.setOnCall("sched-002", []string{"user-alice"}) // Same user in both
-
+	mock.addSchedule("sched-002", "Secondary", "UTC")
+	mock.addSchedule("sched-003", "Tertiary", "UTC")
+	mock.setSchedulesListKey("scheduls")
 	srv := mock.serve()
 	defer srv.Close()
 	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-002"})
-
-	aliceCount := 0
-	for _, r := range results {
-		for _, u := range r.OnCallUsers {
-			if u.UserID == "user-alice" {
-				aliceCount++
-			}
+	// Deterministically empty, not an error, on every call
+	for i := 0; i < 3; i++ {
+		resp, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+		if err != nil {
+			t.Fatalf("FUNC-WRONG-KEY FAIL: Call %d errored: %v", i+1, err)
+		}
+		if len(resp.Schedules) != 0 {
+			t.Fatalf("FUNC-WRONG-KEY FAIL: Call %d returned %d schedules from a misspelled key", i+1, len(resp.Schedules))
 		}
 	}
+	t.Log("FUNC-WRONG-KEY FINDING: SDK has no strict decode mode; an unknown top-level key yields zero schedules and no error")
 
-	if aliceCount != 2 {
-		t.Fatalf("FUNC-DEDUP FAIL: Alice should appear in 2 schedules, appeared in %d", aliceCount)
+	// Count verification catches it, since pagination_meta still reports 3
+	_, err := listAllSchedulesWithOptions(context.Background(), client, listAllOptions{VerifyCount: true})
+	if !errors.Is(err, errCountMismatch) {
+		t.Errorf("FUNC-WRONG-KEY FAIL: Expected errCountMismatch under count verification, got %v", err)
 	}
 
-	t.Log("FUNC-DEDUP PASS: Same user correctly appears in both schedule groups")
-	t.Log("FUNC-DEDUP INFO: Each schedule gets its own group — user in both groups is correct behavior")
+	t.Logf("FUNC-WRONG-KEY PASS: Misspelled key yields zero schedules; verified listing reports: %v", err)
 }
 
-func TestFUNC_PartialFailureDuringSyncContinuesOthers(t *testing.T) {
+func TestFUNC_EscalationOnCallByLevel(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-ok-1", "Good Schedule 1", "UTC")
-	mock.addSchedule("sched-fail", "Failing Schedule", "UTC")
-	mock.addSchedule("sched-ok-2", "Good Schedule 2", "UTC")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addSchedule("sched-002", "Secondary", "UTC")
 	mock.addUser("user-1", "User One", "one@example.com", "responder")
 	mock.addUser("user-2", "User Two", "two@example.com", "responder")
-	mock.setOnCall("sched-ok-1", []string{"user-1"})
-	mock.setOnCall("sched-fail", []string{"user-1"})
-	mock.setOnCall("sched-ok-2", []string{"user-2"})
-
-	// Make one schedule fail
-	mock.failSchedule("sched-fail", true)
-
+	mock.addUser("user-3", "User Three", "three@example.com", "responder")
+	mock.addUser("user-manager", "Manager", "manager@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1", "user-2"})
+	mock.setOnCall("sched-002", []string{"user-3", "user-1"})
+	mock.addEscalationPath("esc-001", "Primary Escalation", []mockEscalationStep{
+		{Targets: []string{"sched-001"}, TimeToAckSeconds: 300},
+		{Targets: []string{"sched-002", "user-manager", "user-3"}, TimeToAckSeconds: 600},
+	})
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	results, err := simulateFullSync(context.Background(), client, []string{"sched-ok-1", "sched-fail", "sched-ok-2"})
+	// The SDK has no escalation path methods yet
+	resp := rawGet(t, srv, "/v2/escalation_paths/esc-001", nil)
+	var body struct {
+		EscalationPath mockEscalationPath `json:"escalation_path"`
+	}
+	err := json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
 	if err != nil {
-		t.Fatalf("FUNC-PARTIAL FAIL: Full sync should not fail entirely: %v", err)
+		t.Fatalf("FUNC-ESCALATION-ONCALL FAIL: Decode: %v", err)
+	}
+	var levels [][]string
+	for _, step := range body.EscalationPath.Steps {
+		levels = append(levels, step.Targets)
 	}
 
-	var okCount, failCount int
-	for _, r := range results {
-		if r.Error != nil {
-			failCount++
-			t.Logf("FUNC-PARTIAL INFO: %s failed: %v", r.ScheduleID, r.Error)
-		} else {
-			okCount++
-			t.Logf("FUNC-PARTIAL INFO: %s synced OK with %d users", r.ScheduleID, len(r.OnCallUsers))
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	onCall, err := escalationOnCall(context.Background(), client, levels)
+	if err != nil {
+		t.Fatalf("FUNC-ESCALATION-ONCALL FAIL: %v", err)
+	}
+	var got [][]string
+	for _, users := range onCall {
+		ids := []string{}
+		for _, u := range users {
+			ids = append(ids, u.ID)
 		}
+		got = append(got, ids)
 	}
-
-	if okCount != 2 {
-		t.Fatalf("FUNC-PARTIAL FAIL: Expected 2 successful syncs, got %d", okCount)
+	want := [][]string{{"user-1", "user-2"}, {"user-3", "user-1", "user-manager"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FUNC-ESCALATION-ONCALL FAIL: Expected %v, got %v", want, got)
 	}
-	if failCount != 1 {
-		t.Fatalf("FUNC-PARTIAL FAIL: Expected 1 failed sync, got %d", failCount)
+
+	if _, err := escalationOnCall(context.Background(), client, [][]string{{"sched-gone"}}); err == nil {
+		t.Error("FUNC-ESCALATION-ONCALL FAIL: Unknown target should error")
 	}
 
-	t.Log("FUNC-PARTIAL PASS: Failed schedule didn't block others — 2 synced, 1 failed")
+	t.Logf("FUNC-ESCALATION-ONCALL PASS: %d levels resolved in order: %v", len(got), got)
 }
 
-func TestFUNC_APIDegradationDuringMultiStepSync(t *testing.T) {
+func TestFUNC_RetryOnStatusTransport(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "On-Call", "UTC")
-	mock.addUser("user-1", "User One", "one@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-1"})
-
+	mock.addSchedule("sched-001", "Primary", "UTC")
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	client := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: retryOnStatusTransport(nil, 3, 500)}),
+	)
 
-	// Step 1: Normal sync works
-	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if err != nil || results[0].Error != nil {
-		t.Fatalf("FUNC-DEGRADE FAIL: Initial sync should work")
+	// A single 500 then 200 recovers
+	mock.scenario().onPath("/v2/schedules").fail(500).recoverAfter(1).apply()
+	schedules, err := listAllSchedules(context.Background(), client)
+	if err != nil || len(schedules) != 1 {
+		t.Fatalf("FUNC-RETRY-STATUS FAIL: 500 then 200 should recover, got %d schedules (err=%v)", len(schedules), err)
+	}
+	if n := mock.getRequestCount(); n != 2 {
+		t.Errorf("FUNC-RETRY-STATUS FAIL: Expected 2 requests, got %d", n)
 	}
 
-	// Step 2: User endpoint goes down mid-sync
-	mock.failEndpoint("/v2/users", 503)
-	results, err = simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if err != nil {
-		t.Fatalf("FUNC-DEGRADE FAIL: Sync should still return results even with user failures: %v", err)
+	// A persistent 500 stops after 3 attempts and surfaces the real status
+	mock.clearScenario("/v2/schedules")
+	mock.resetRequestLog()
+	mock.scenario().onPath("/v2/schedules").fail(500).apply()
+	_, err = listAllSchedules(context.Background(), client)
+	var apiErr *incidentio.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 500 {
+		t.Fatalf("FUNC-RETRY-STATUS FAIL: Expected a 500 APIError, got %v", err)
 	}
-	// Schedule entries work, but user resolution fails → 0 resolved users
-	if results[0].Error != nil {
-		t.Logf("FUNC-DEGRADE INFO: Schedule synced with error: %v", results[0].Error)
-	} else if len(results[0].OnCallUsers) != 0 {
-		t.Logf("FUNC-DEGRADE INFO: Unexpectedly resolved %d users with user endpoint down", len(results[0].OnCallUsers))
-	} else {
-		t.Log("FUNC-DEGRADE INFO: 0 users resolved (user endpoint down) — schedule entry found but user lookup failed")
+	if n := mock.getRequestCount(); n != 3 {
+		t.Errorf("FUNC-RETRY-STATUS FAIL: Expected 3 attempts, got %d", n)
 	}
 
-	// Step 3: User endpoint recovers
-	mock.failEndpoint("/v2/users", 0)
-	results, err = simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if err != nil || results[0].Error != nil {
-		t.Fatalf("FUNC-DEGRADE FAIL: Recovery sync should work")
+	// Statuses outside the set are not retried
+	mock.clearScenario("/v2/schedules")
+	mock.resetRequestLog()
+	mock.scenario().onPath("/v2/schedules").fail(503).apply()
+	if _, err := listAllSchedules(context.Background(), client); err == nil {
+		t.Fatal("FUNC-RETRY-STATUS FAIL: 503 should fail")
 	}
-	if len(results[0].OnCallUsers) != 1 {
-		t.Fatalf("FUNC-DEGRADE FAIL: Expected 1 user after recovery, got %d", len(results[0].OnCallUsers))
+	if n := mock.getRequestCount(); n != 1 {
+		t.Errorf("FUNC-RETRY-STATUS FAIL: 503 is not in the set, expected 1 request, got %d", n)
 	}
 
-	t.Log("FUNC-DEGRADE PASS: Handled API degradation and recovery")
+	t.Log("FUNC-RETRY-STATUS PASS: 500 added to the retryable set without touching other statuses")
 }
 
-func TestFUNC_LargeScaleSync(t *testing.T) {
-	mock := newMockIncidentIO("scale-key")
-
-	// Create 50 schedules with 5 users each
-	for i := 0; i < 50; i++ {
-		schedID := fmt.Sprintf("sched-%03d", i)
-		mock.addSchedule(schedID, fmt.Sprintf("Schedule %d", i), "UTC")
-		var onCallUsers []string
-		for j := 0; j < 5; j++ {
-			userID := fmt.Sprintf("user-%03d-%d", i, j)
-			mock.addUser(userID, fmt.Sprintf("User %d-%d", i, j), fmt.Sprintf("user%d_%d@example.com", i, j), "responder")
-			onCallUsers = append(onCallUsers, userID)
-		}
-		mock.setOnCall(schedID, onCallUsers)
-	}
-
+func TestFUNC_MockTTFBDelaySeparateFromBody(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary", "UTC")
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("scale-key", incidentio.WithBaseURL(srv.URL))
 
-	// List all schedules
+	// Stands in for a per-attempt header timeout (see DEFERRED_SDK_REQUESTS.md)
+	const headerTimeout = 500 * time.Millisecond
+	client := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: &http.Transport{ResponseHeaderTimeout: headerTimeout}}),
+	)
+
+	// Slow first byte: aborts at the header timeout, before any body
+	mock.scenario().onPath("/v2/schedules").latency(time.Second).apply()
 	start := time.Now()
-	schedules, err := listAllSchedules(context.Background(), client)
-	listDuration := time.Since(start)
-	if err != nil {
-		t.Fatalf("FUNC-SCALE FAIL: List schedules: %v", err)
-	}
-	if len(schedules) != 50 {
-		t.Fatalf("FUNC-SCALE FAIL: Expected 50 schedules, got %d", len(schedules))
+	_, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	elapsed := time.Since(start)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("FUNC-MOCK-TTFB FAIL: Expected a header timeout, got %v", err)
 	}
-
-	// Sync all 50 schedules
-	tracked := make([]string, 50)
-	for i := 0; i < 50; i++ {
-		tracked[i] = fmt.Sprintf("sched-%03d", i)
+	if elapsed >= time.Second {
+		t.Errorf("FUNC-MOCK-TTFB FAIL: Expected abort at ~%v, before the 1s first byte; took %v", headerTimeout, elapsed)
 	}
 
+	// Fast headers, slow body: the header timeout does not apply
+	mock.clearScenario("/v2/schedules")
+	mock.scenario().onPath("/v2/schedules").bodyLatency(time.Second).apply()
 	start = time.Now()
-	results, err := simulateFullSync(context.Background(), client, tracked)
-	syncDuration := time.Since(start)
-	if err != nil {
-		t.Fatalf("FUNC-SCALE FAIL: Sync: %v", err)
+	resp, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	if err != nil || len(resp.Schedules) != 1 {
+		t.Fatalf("FUNC-MOCK-TTFB FAIL: Slow body after prompt headers should succeed: %v", err)
 	}
-
-	totalUsers := 0
-	errors := 0
-	for _, r := range results {
-		if r.Error != nil {
-			errors++
-		} else {
-			totalUsers += len(r.OnCallUsers)
-		}
+	if d := time.Since(start); d < time.Second {
+		t.Errorf("FUNC-MOCK-TTFB FAIL: Expected the body delayed by 1s, took %v", d)
 	}
 
-	t.Logf("FUNC-SCALE PASS: 50 schedules, %d users resolved, %d errors", totalUsers, errors)
-	t.Logf("FUNC-SCALE INFO: List took %v, sync took %v, %d API calls total",
-		listDuration, syncDuration, mock.getRequestCount())
+	t.Logf("FUNC-MOCK-TTFB PASS: 1s TTFB aborted after %v; 1s body delay passed the header timeout", elapsed.Round(time.Millisecond))
+}
 
-	if totalUsers != 250 {
-		t.Errorf("FUNC-SCALE WARNING: Expected 250 total users (50x5), got %d", totalUsers)
+func TestFUNC_MockDeactivatedUsers(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addUser("user-active", "Active", "active@example.com", "responder")
+	mock.addUser("user-gone", "Gone", "gone@example.com", "responder")
+	mock.deactivateUser("user-gone")
+	mock.setOnCall("sched-001", []string{"user-active", "user-gone"})
+	srv := mock.serve()
+	defer srv.Close()
+
+	// The SDK's User has no Deactivated field yet
+	resp := rawGet(t, srv, "/v2/users/user-gone", nil)
+	var body struct {
+		User mockUser `json:"user"`
+	}
+	err := json.NewDecoder(resp.Body).Decode(&body)
+	resp.Body.Close()
+	if err != nil || !body.User.Deactivated {
+		t.Fatalf("FUNC-DEACTIVATED FAIL: Expected deactivated=true in the payload, got %+v (err=%v)", body.User, err)
+	}
+
+	// The SDK decodes the payload cleanly, dropping the flag
+	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	user, err := client.GetUserWithContext(context.Background(), "user-gone", incidentio.GetUserOptions{})
+	if err != nil || user.ID != "user-gone" {
+		t.Fatalf("FUNC-DEACTIVATED FAIL: Payload with deactivated should decode: %v", err)
+	}
+
+	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
+	if err != nil {
+		t.Fatalf("FUNC-DEACTIVATED FAIL: %v", err)
+	}
+	for _, u := range results[0].OnCallUsers {
+		if u.UserID == "user-gone" {
+			t.Log("FUNC-DEACTIVATED FINDING: Deactivated on-call user is still provisioned; sync cannot exclude it until User carries Deactivated")
+		}
 	}
+
+	t.Log("FUNC-DEACTIVATED PASS: Mock serves deactivated users and the SDK decodes them")
 }
 
-func TestFUNC_ConcurrentSyncs(t *testing.T) {
-	mock := newMockIncidentIO("concurrent-key")
-	mock.addSchedule("sched-001", "On-Call", "UTC")
-	mock.addUser("user-1", "User One", "one@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-1"})
+func TestFUNC_ValidateAPIKey(t *testing.T) {
+	for _, tc := range []struct {
+		key   string
+		valid bool
+	}{
+		{validAPIKey, true},
+		{"", false},
+		{validAPIKey + "\n", false},
+		{" " + validAPIKey, false},
+		{"Bearer " + validAPIKey, false},
+		{"test-api key", false},
+		{"test-api\tkey", false},
+	} {
+		if err := validateAPIKey(tc.key); (err == nil) != tc.valid {
+			t.Errorf("FUNC-VALIDATE-KEY FAIL: validateAPIKey(%q) = %v, want valid=%v", tc.key, err, tc.valid)
+		}
+	}
 
-	srv := mock.serve()
+	// A pasted key with a trailing newline is trimmed and authenticates
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+validAPIKey {
+			w.WriteHeader(401)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules":       []interface{}{},
+			"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": 0},
+		})
+	}))
 	defer srv.Close()
-	client := incidentio.NewClient("concurrent-key", incidentio.WithBaseURL(srv.URL))
-
-	// Run 10 syncs concurrently (simulates periodic sync + manual sync race)
-	const concurrency = 10
-	var wg sync.WaitGroup
-	var errCount int32
-	var successCount int32
-
-	wg.Add(concurrency)
-	for i := 0; i < concurrency; i++ {
-		go func() {
-			defer wg.Done()
-			results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
-			if err != nil || results[0].Error != nil {
-				atomic.AddInt32(&errCount, 1)
-				return
-			}
-			if len(results[0].OnCallUsers) == 1 {
-				atomic.AddInt32(&successCount, 1)
-			}
-		}()
+	key, err := normalizeAPIKey(validAPIKey + "\n")
+	if err != nil {
+		t.Fatalf("FUNC-VALIDATE-KEY FAIL: Trailing newline should be trimmed: %v", err)
+	}
+	client := incidentio.NewClient(key, incidentio.WithBaseURL(srv.URL))
+	if _, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{}); err != nil {
+		t.Fatalf("FUNC-VALIDATE-KEY FAIL: Trimmed key should authenticate: %v", err)
 	}
-	wg.Wait()
 
-	t.Logf("FUNC-CONCURRENT PASS: %d/%d concurrent syncs succeeded, %d failed",
-		successCount, concurrency, errCount)
-	if errCount > 0 {
-		t.Logf("FUNC-CONCURRENT FINDING: %d concurrent syncs failed — possible contention", errCount)
+	// A blank key is rejected pre-flight, unlike AUTH-003 where it reaches the client
+	if _, err := normalizeAPIKey("  \n"); err == nil {
+		t.Error("FUNC-VALIDATE-KEY FAIL: Blank key should be rejected")
 	}
+
+	t.Log("FUNC-VALIDATE-KEY PASS: Malformed keys rejected locally; pasted whitespace trimmed")
 }
 
-func TestFUNC_ScheduleAddedThenImmediatelyRemoved(t *testing.T) {
+func TestFUNC_ContextScopedRequestLogger(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "Permanent", "UTC")
+	mock.addSchedule("sched-001", "Primary", "UTC")
 	mock.addUser("user-1", "User One", "one@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-1"})
-
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	client := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: requestLoggerTransport(nil)}),
+	)
 
-	// Add a schedule
-	mock.addSchedule("sched-temp", "Temporary", "UTC")
-	mock.setOnCall("sched-temp", []string{"user-1"})
+	var logged []requestLogEntry
+	ctx := withRequestLogger(context.Background(), func(e requestLogEntry) { logged = append(logged, e) })
 
-	// Sync with it
-	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-temp"})
-	if len(results) != 2 {
-		t.Fatalf("FUNC-ADD-REMOVE FAIL: Expected 2 results after add, got %d", len(results))
+	// Only the call carrying the logger is reported
+	if _, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{}); err != nil {
+		t.Fatalf("FUNC-CTX-LOGGER FAIL: %v", err)
+	}
+	if _, err := client.GetUserWithContext(ctx, "user-1", incidentio.GetUserOptions{}); err != nil {
+		t.Fatalf("FUNC-CTX-LOGGER FAIL: %v", err)
+	}
+	if _, err := client.GetUserWithContext(ctx, "user-missing", incidentio.GetUserOptions{}); err == nil {
+		t.Fatal("FUNC-CTX-LOGGER FAIL: Missing user should error")
 	}
 
-	// Immediately remove from incident.io AND from tracking
-	mock.removeSchedule("sched-temp")
-	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if len(results) != 1 {
-		t.Fatalf("FUNC-ADD-REMOVE FAIL: Expected 1 result after remove, got %d", len(results))
+	if len(logged) != 2 {
+		t.Fatalf("FUNC-CTX-LOGGER FAIL: Expected 2 logged calls, got %d: %+v", len(logged), logged)
+	}
+	if logged[0].Method != "GET" || logged[0].Path != "/v2/users/user-1" || logged[0].Status != 200 {
+		t.Errorf("FUNC-CTX-LOGGER FAIL: Unexpected first entry %+v", logged[0])
+	}
+	if logged[1].Path != "/v2/users/user-missing" || logged[1].Status != 404 {
+		t.Errorf("FUNC-CTX-LOGGER FAIL: Unexpected second entry %+v", logged[1])
 	}
 
-	t.Log("FUNC-ADD-REMOVE PASS: Schedule added then immediately removed — clean state")
+	t.Logf("FUNC-CTX-LOGGER PASS: Context logger saw only its own %d calls", len(logged))
 }
 
-func TestFUNC_UserDeletedFromIncidentIO(t *testing.T) {
+func TestFUNC_GroupResultsByUser(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "On-Call", "UTC")
-	mock.addUser("user-active", "Active User", "active@example.com", "responder")
-	mock.addUser("user-departing", "Departing User", "departing@example.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-active", "user-departing"})
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addSchedule("sched-002", "Escalation", "UTC")
+	mock.addSchedule("sched-fail", "Failing", "UTC")
+	mock.addUser("user-alice", "Alice", "alice@example.com", "responder")
+	mock.addUser("user-bob", "Bob", "bob@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-alice"})
+	mock.setOnCall("sched-002", []string{"user-alice", "user-bob"})
+	mock.setOnCall("sched-fail", []string{"user-bob"})
+	mock.failSchedule("sched-fail", true)
 
 	srv := mock.serve()
 	defer srv.Close()
 	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	// Sync 1: Both users on-call
-	results, _ := simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if len(results[0].OnCallUsers) != 2 {
-		t.Fatalf("FUNC-USER-DELETE FAIL: Expected 2 users, got %d", len(results[0].OnCallUsers))
-	}
-
-	// User departs — still in on-call entries but user endpoint returns 404
-	// (simulates delay between schedule update and user removal)
-	mock.mu.Lock()
-	delete(mock.users, "user-departing")
-	mock.mu.Unlock()
-
-	// Sync 2: Departing user still in entries but can't be resolved
-	results, _ = simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if len(results[0].OnCallUsers) != 1 {
-		t.Fatalf("FUNC-USER-DELETE FAIL: Expected 1 resolved user after deletion, got %d", len(results[0].OnCallUsers))
+	results, _ := simulateFullSync(context.Background(), client, []string{"sched-002", "sched-001", "sched-fail"})
+	got := groupResultsByUser(results)
+	want := map[string][]string{
+		"user-alice": {"sched-001", "sched-002"},
+		"user-bob":   {"sched-002"},
 	}
-	if results[0].OnCallUsers[0].UserID != "user-active" {
-		t.Fatalf("FUNC-USER-DELETE FAIL: Expected active user, got %s", results[0].OnCallUsers[0].UserID)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("FUNC-GROUP-BY-USER FAIL: Expected %v, got %v", want, got)
 	}
 
-	t.Log("FUNC-USER-DELETE PASS: Deleted user gracefully skipped, active user still resolved")
+	t.Logf("FUNC-GROUP-BY-USER PASS: %v", got)
 }
 
-func TestFUNC_ManySchedulesSomeFailingOthersSucceed(t *testing.T) {
+func TestFUNC_UserLookupConcurrencyBoundedIndependently(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	for i := 0; i < 10; i++ {
-		id := fmt.Sprintf("sched-%02d", i)
-		mock.addSchedule(id, fmt.Sprintf("Schedule %d", i), "UTC")
-		mock.addUser(fmt.Sprintf("user-%02d", i), fmt.Sprintf("User %d", i), fmt.Sprintf("u%d@example.com", i), "responder")
-		mock.setOnCall(id, []string{fmt.Sprintf("user-%02d", i)})
+	var tracked []string
+	for i := 0; i < 8; i++ {
+		schedID := fmt.Sprintf("sched-%03d", i)
+		userID := fmt.Sprintf("user-%03d", i)
+		mock.addSchedule(schedID, fmt.Sprintf("Schedule %d", i), "UTC")
+		mock.addUser(userID, fmt.Sprintf("User %d", i), fmt.Sprintf("user%d@example.com", i), "responder")
+		mock.setOnCall(schedID, []string{userID})
+		tracked = append(tracked, schedID)
 	}
-
-	// Fail schedules 3, 5, 7
-	mock.failSchedule("sched-03", true)
-	mock.failSchedule("sched-05", true)
-	mock.failSchedule("sched-07", true)
-
+	mock.scenario().onPath("/v2/users/").latency(50 * time.Millisecond).apply()
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	tracked := make([]string, 10)
-	for i := 0; i < 10; i++ {
-		tracked[i] = fmt.Sprintf("sched-%02d", i)
-	}
+	// Count GetUser calls in flight client-side; other endpoints don't count
+	var inFlight, maxInFlight int32
+	countUsers := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if !strings.HasPrefix(r.URL.Path, "/v2/users/") {
+			return http.DefaultTransport.RoundTrip(r)
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	client := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: countUsers}),
+	)
 
-	results, err := simulateFullSync(context.Background(), client, tracked)
+	results, err := simulateFullSyncConcurrentWithOptions(context.Background(), client, tracked, 8, syncOptions{MaxConcurrentUserLookups: 2})
 	if err != nil {
-		t.Fatalf("FUNC-MIXED FAIL: Overall sync should not fail: %v", err)
+		t.Fatalf("FUNC-USER-CONCURRENCY FAIL: %v", err)
 	}
-
-	successIDs := []string{}
-	failIDs := []string{}
 	for _, r := range results {
-		if r.Error != nil {
-			failIDs = append(failIDs, r.ScheduleID)
-		} else {
-			successIDs = append(successIDs, r.ScheduleID)
+		if r.Error != nil || len(r.OnCallUsers) != 1 {
+			t.Fatalf("FUNC-USER-CONCURRENCY FAIL: %s: %d users, err=%v", r.ScheduleID, len(r.OnCallUsers), r.Error)
 		}
 	}
+	if got := atomic.LoadInt32(&maxInFlight); got > 2 {
+		t.Errorf("FUNC-USER-CONCURRENCY FAIL: Expected at most 2 user lookups in flight, saw %d", got)
+	}
 
-	if len(successIDs) != 7 {
-		t.Fatalf("FUNC-MIXED FAIL: Expected 7 successes, got %d: %v", len(successIDs), successIDs)
+	// Without the user limit, lookups follow schedule concurrency
+	atomic.StoreInt32(&maxInFlight, 0)
+	if _, err := simulateFullSyncConcurrent(context.Background(), client, tracked, 8); err != nil {
+		t.Fatalf("FUNC-USER-CONCURRENCY FAIL: %v", err)
 	}
-	if len(failIDs) != 3 {
-		t.Fatalf("FUNC-MIXED FAIL: Expected 3 failures, got %d: %v", len(failIDs), failIDs)
+	unbounded := atomic.LoadInt32(&maxInFlight)
+	if unbounded <= 2 {
+		t.Logf("FUNC-USER-CONCURRENCY INFO: Only %d user lookups overlapped without the limit", unbounded)
 	}
 
-	t.Logf("FUNC-MIXED PASS: 7 schedules synced, 3 failed individually (no cascade)")
+	t.Logf("FUNC-USER-CONCURRENCY PASS: User lookups capped at 2 with 8 schedules in parallel (%d without the cap)", unbounded)
 }
 
-func TestFUNC_APIRequestCounting(t *testing.T) {
+func TestFUNC_UserLookupWaitHonorsCancel(t *testing.T) {
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "On-Call 1", "UTC")
-	mock.addSchedule("sched-002", "On-Call 2", "UTC")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addSchedule("sched-002", "Secondary", "UTC")
 	mock.addUser("user-1", "User One", "one@example.com", "responder")
 	mock.addUser("user-2", "User Two", "two@example.com", "responder")
 	mock.setOnCall("sched-001", []string{"user-1"})
 	mock.setOnCall("sched-002", []string{"user-2"})
-
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
 
-	mock.resetRequestLog()
-	_, err := simulateFullSync(context.Background(), client, []string{"sched-001", "sched-002"})
-	if err != nil {
-		t.Fatalf("FUNC-API-COUNT FAIL: %v", err)
-	}
+	// The first user lookup holds the only slot until released, ignoring
+	// cancellation, so the other schedule has to wait for it
+	var lookups, entriesDone int32
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if strings.HasPrefix(r.URL.Path, "/v2/users/") {
+			atomic.AddInt32(&lookups, 1)
+			select {
+			case entered <- struct{}{}:
+			default:
+			}
+			<-release
+			return http.DefaultTransport.RoundTrip(r)
+		}
+		resp, err := http.DefaultTransport.RoundTrip(r)
+		if r.URL.Path == "/v2/schedule_entries" {
+			atomic.AddInt32(&entriesDone, 1)
+		}
+		return resp, err
+	})
+	client := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan []syncResult, 1)
+	go func() {
+		results, err := simulateFullSyncConcurrentWithOptions(ctx, client, []string{"sched-001", "sched-002"}, 2, syncOptions{MaxConcurrentUserLookups: 1})
+		if err != nil {
+			t.Errorf("FUNC-USER-WAIT-CANCEL FAIL: %v", err)
+		}
+		done <- results
+	}()
 
-	log := mock.getRequestLog()
-	count := mock.getRequestCount()
+	<-entered
+	for atomic.LoadInt32(&entriesDone) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	close(release)
 
-	// Expected: 1 list schedules + 2 schedule entries + 2 get user = 5 minimum
-	t.Logf("FUNC-API-COUNT PASS: Sync made %d API calls", count)
-	for _, entry := range log {
-		t.Logf("  %s", entry)
+	var results []syncResult
+	select {
+	case results = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("FUNC-USER-WAIT-CANCEL FAIL: Sync did not return after cancellation")
 	}
 
-	if count > 20 {
-		t.Logf("FUNC-API-COUNT FINDING: %d API calls seems high for 2 schedules with 1 user each", count)
+	if got := atomic.LoadInt32(&lookups); got != 1 {
+		t.Errorf("FUNC-USER-WAIT-CANCEL FAIL: Expected 1 user lookup, got %d; the waiting schedule looked up after cancellation", got)
+	}
+	aborted := 0
+	for _, r := range results {
+		if r.Error != nil && strings.Contains(r.Error.Error(), "user resolution aborted") {
+			if !errors.Is(r.Error, context.Canceled) {
+				t.Errorf("FUNC-USER-WAIT-CANCEL FAIL: %s: expected context.Canceled, got %v", r.ScheduleID, r.Error)
+			}
+			aborted++
+		}
+	}
+	if aborted != 1 {
+		t.Fatalf("FUNC-USER-WAIT-CANCEL FAIL: Expected the waiting schedule aborted, got %+v", results)
 	}
-}
 
-func TestFUNC_ScheduleWithManyOnCallUsers(t *testing.T) {
-	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-big", "Big Schedule", "UTC")
+	t.Logf("FUNC-USER-WAIT-CANCEL PASS: Schedule waiting for a lookup slot aborted on cancel")
+}
 
-	// 20 users on-call simultaneously
-	var userIDs []string
-	for i := 0; i < 20; i++ {
-		id := fmt.Sprintf("user-%03d", i)
-		mock.addUser(id, fmt.Sprintf("User %d", i), fmt.Sprintf("user%d@example.com", i), "responder")
-		userIDs = append(userIDs, id)
+func TestFUNC_RequestLatencyBucket(t *testing.T) {
+	for _, tc := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, latencyUnder100ms},
+		{99 * time.Millisecond, latencyUnder100ms},
+		{100 * time.Millisecond, latencyUnder500ms},
+		{500 * time.Millisecond, latencyUnder1s},
+		{time.Second, latencyOver1s},
+	} {
+		if got := latencyBucket(tc.d); got != tc.want {
+			t.Errorf("FUNC-LATENCY-BUCKET FAIL: latencyBucket(%v) = %q, want %q", tc.d, got, tc.want)
+		}
 	}
-	mock.setOnCall("sched-big", userIDs)
 
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.scenario().onPath("/v2/schedules").latency(600 * time.Millisecond).apply()
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
-
-	results, _ := simulateFullSync(context.Background(), client, []string{"sched-big"})
-	if results[0].Error != nil {
-		t.Fatalf("FUNC-BIG-ONCALL FAIL: %v", results[0].Error)
+	client := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: requestLoggerTransport(nil)}),
+	)
+
+	var logged []requestLogEntry
+	ctx := withRequestLogger(context.Background(), func(e requestLogEntry) { logged = append(logged, e) })
+	if _, err := client.ListSchedulesWithContext(ctx, incidentio.ListSchedulesOptions{}); err != nil {
+		t.Fatalf("FUNC-LATENCY-BUCKET FAIL: %v", err)
 	}
-	if len(results[0].OnCallUsers) != 20 {
-		t.Fatalf("FUNC-BIG-ONCALL FAIL: Expected 20 users, got %d", len(results[0].OnCallUsers))
+	if _, err := client.GetUserWithContext(ctx, "user-1", incidentio.GetUserOptions{}); err != nil {
+		t.Fatalf("FUNC-LATENCY-BUCKET FAIL: %v", err)
+	}
+	if len(logged) != 2 {
+		t.Fatalf("FUNC-LATENCY-BUCKET FAIL: Expected 2 logged calls, got %d", len(logged))
+	}
+	// Only the slow side is asserted; the fast call's bucket depends on the machine
+	if logged[0].Bucket != latencyUnder1s {
+		t.Errorf("FUNC-LATENCY-BUCKET FAIL: 600ms call reported %q (%v), want %q", logged[0].Bucket, logged[0].Duration, latencyUnder1s)
+	}
+	if logged[1].Bucket != latencyBucket(logged[1].Duration) {
+		t.Errorf("FUNC-LATENCY-BUCKET FAIL: Bucket %q does not match duration %v", logged[1].Bucket, logged[1].Duration)
 	}
 
-	t.Logf("FUNC-BIG-ONCALL PASS: 20 concurrent on-call users resolved correctly")
+	t.Logf("FUNC-LATENCY-BUCKET PASS: %v -> %s, %v -> %s", logged[0].Duration.Round(time.Millisecond), logged[0].Bucket, logged[1].Duration.Round(time.Millisecond), logged[1].Bucket)
 }
 
-func TestFUNC_EntireAPIDown(t *testing.T) {
+func TestFUNC_StatsTransportCountsRetries(t *testing.T) {
+	// Rate limited twice, then success
+	var attempts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"type": "rate_limited", "status": 429, "message": "Rate limited",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"schedules":       []interface{}{},
+			"pagination_meta": map[string]interface{}{"after": "", "page_size": 250, "total_record_count": 0},
+		})
+	}))
+	defer srv.Close()
+
+	stats := newStatsTransport(nil)
+	client := incidentio.NewClient(validAPIKey,
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: stats}),
+	)
+	if _, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{}); err != nil {
+		t.Fatalf("FUNC-STATS FAIL: Should succeed after retries: %v", err)
+	}
+	want := transportStats{Requests: 3, Retries: 2, Status4xx: 2}
+	if got := stats.stats(); got != want {
+		t.Fatalf("FUNC-STATS FAIL: Expected %+v, got %+v", want, got)
+	}
+
+	// Other outcomes land in their own counters and are not retries
 	mock := newMockIncidentIO("test-key")
-	mock.addSchedule("sched-001", "On-Call", "UTC")
+	mock.addSchedule("sched-001", "Primary", "UTC")
+	mock.failSchedule("sched-002", true)
+	msrv := mock.serve()
+	stats = newStatsTransport(nil)
+	withKey := func(key, baseURL string) *incidentio.Client {
+		return incidentio.NewClient(key,
+			incidentio.WithBaseURL(baseURL),
+			incidentio.WithHTTPClient(&http.Client{Transport: stats}),
+		)
+	}
+	withKey("test-key", msrv.URL).ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	withKey("test-key", msrv.URL).ListScheduleEntriesWithContext(context.Background(), incidentio.ListScheduleEntriesOptions{ScheduleID: "sched-002"})
+	withKey("wrong-key", msrv.URL).ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	msrv.Close()
+	withKey("test-key", msrv.URL).ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
+	want = transportStats{Requests: 4, Status4xx: 1, Status5xx: 1, TransportErrors: 1}
+	if got := stats.stats(); got != want {
+		t.Fatalf("FUNC-STATS FAIL: Expected %+v, got %+v", want, got)
+	}
+
+	t.Log("FUNC-STATS PASS: 429, 429, 200 counted as 3 requests and 2 retries")
+}
+
+// ============================================================================
+// FUNCTIONAL BENCHMARKS
+// ============================================================================
+
+// BenchmarkFUNC_ListSchedules50 is the allocation baseline for a 50-schedule
+// list loop; compare with -benchmem before and after SDK body-reading changes.
+func BenchmarkFUNC_ListSchedules50(b *testing.B) {
+	mock := newMockIncidentIO("bench-key")
+	for i := 0; i < 50; i++ {
+		mock.addSchedule(fmt.Sprintf("sched-%03d", i), fmt.Sprintf("Schedule %d", i), "UTC")
+	}
 
 	srv := mock.serve()
 	defer srv.Close()
-	client := incidentio.NewClient("test-key", incidentio.WithBaseURL(srv.URL))
+	client := incidentio.NewClient("bench-key", incidentio.WithBaseURL(srv.URL))
 
-	// Fail the list schedules endpoint — first step of sync
-	mock.failEndpoint("/v2/schedules", 503)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		schedules, err := listAllSchedules(context.Background(), client)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(schedules) != 50 {
+			b.Fatalf("expected 50 schedules, got %d", len(schedules))
+		}
+	}
+}
 
-	_, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if err == nil {
-		t.Fatal("FUNC-API-DOWN FAIL: Should fail when entire API is down")
+// BenchmarkFUNC_ListSchedulesLargeBody decodes a response just under the SDK's
+// 10MB cap and reports the peak live heap seen during each call, above the
+// heap before it, as a multiple of the body size. The SDK buffers the whole
+// body before decoding, so this is the baseline a streaming decoder would
+// lower. It only reports; compare runs with -bench before and after.
+func BenchmarkFUNC_ListSchedulesLargeBody(b *testing.B) {
+	schedules := make([]map[string]interface{}, 0, 1000)
+	name := strings.Repeat("A", 9*1024)
+	for i := 0; i < 1000; i++ {
+		schedules = append(schedules, map[string]interface{}{"id": fmt.Sprintf("sched-%04d", i), "name": name, "timezone": "UTC"})
 	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"schedules":       schedules,
+		"pagination_meta": map[string]interface{}{"after": "", "page_size": 1000, "total_record_count": 1000},
+	})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	defer srv.Close()
+	client := incidentio.NewClient(validAPIKey, incidentio.WithBaseURL(srv.URL))
 
-	t.Logf("FUNC-API-DOWN PASS: Full API outage handled: %v", err)
+	// Live heap objects, readable without stopping the world
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	heapBytes := func() uint64 {
+		metrics.Read(sample)
+		return sample[0].Value.Uint64()
+	}
 
-	// Recovery
-	mock.failEndpoint("/v2/schedules", 0)
-	mock.addUser("user-1", "User", "u@e.com", "responder")
-	mock.setOnCall("sched-001", []string{"user-1"})
+	var peakOverBase uint64
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		runtime.GC()
+		base := heapBytes()
+		done := make(chan struct{})
+		peak := make(chan uint64)
+		go func() {
+			var max uint64
+			for {
+				if h := heapBytes(); h > max {
+					max = h
+				}
+				select {
+				case <-done:
+					peak <- max
+					return
+				case <-time.After(100 * time.Microsecond):
+				}
+			}
+		}()
+		b.StartTimer()
 
-	results, err := simulateFullSync(context.Background(), client, []string{"sched-001"})
-	if err != nil || results[0].Error != nil {
-		t.Fatalf("FUNC-API-DOWN FAIL: Should recover after API comes back: %v", err)
-	}
+		resp, err := client.ListSchedulesWithContext(context.Background(), incidentio.ListSchedulesOptions{})
 
-	t.Log("FUNC-API-DOWN PASS: API outage and recovery handled correctly")
+		b.StopTimer()
+		close(done)
+		if max := <-peak; max > base && max-base > peakOverBase {
+			peakOverBase = max - base
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(resp.Schedules) != 1000 {
+			b.Fatalf("expected 1000 schedules, got %d", len(resp.Schedules))
+		}
+		b.StartTimer()
+	}
+	b.ReportMetric(float64(peakOverBase)/float64(len(body)), "peak-heap/body")
 }
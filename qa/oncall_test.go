@@ -0,0 +1,551 @@
+package qa
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	incidentio "github.com/strongdm/web/pkg/incidentio/sdk"
+)
+
+// ============================================================================
+// Helper: on-call entry analysis built on SDK types
+// ============================================================================
+
+// overlapPair is two schedule entries whose shifts overlap in time, along with
+// the overlapping window itself.
+type overlapPair struct {
+	First  incidentio.ScheduleEntry
+	Second incidentio.ScheduleEntry
+	Start  time.Time
+	End    time.Time
+}
+
+// coverageGap is an interval in which no schedule entry has anyone on call.
+type coverageGap struct {
+	Start time.Time
+	End   time.Time
+}
+
+// parseEntryWindow parses an entry's start/end times. ok is false if either
+// time is not valid RFC3339 or the entry ends before it starts.
+func parseEntryWindow(entry incidentio.ScheduleEntry) (start, end time.Time, ok bool) {
+	start, err := time.Parse(time.RFC3339, entry.StartAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	end, err = time.Parse(time.RFC3339, entry.EndAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// entryValid reports whether an entry has parseable times and does not end
+// before it starts.
+func entryValid(entry incidentio.ScheduleEntry) bool {
+	_, _, ok := parseEntryWindow(entry)
+	return ok
+}
+
+// entryIsActive reports whether entry covers the instant at, i.e.
+// start <= at < end. Invalid entries are never active.
+func entryIsActive(entry incidentio.ScheduleEntry, at time.Time) bool {
+	start, end, ok := parseEntryWindow(entry)
+	if !ok {
+		return false
+	}
+	return !at.Before(start) && at.Before(end)
+}
+
+// filterEntriesToWindow returns the entries whose [start, end) interval
+// intersects [windowStart, windowEnd), for backends that return shifts
+// outside the requested window. Entries with unparseable times are dropped,
+// since they cannot be placed in the window.
+func filterEntriesToWindow(entries []incidentio.ScheduleEntry, windowStart, windowEnd time.Time) []incidentio.ScheduleEntry {
+	kept := []incidentio.ScheduleEntry{}
+	for _, e := range entries {
+		start, end, ok := parseEntryWindow(e)
+		if ok && start.Before(windowEnd) && end.After(windowStart) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// entryHasUser reports whether entry names a user. An entry that arrived with
+// "user": null (e.g. an unfilled shift) decodes to a zero User.
+func entryHasUser(entry incidentio.ScheduleEntry) bool {
+	return entry.User.ID != ""
+}
+
+// nextHandoff returns the earliest end time after at among entries active at
+// at, i.e. when the on-call next changes. ok is false if nobody is on call.
+func nextHandoff(entries []incidentio.ScheduleEntry, at time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, e := range entries {
+		if !entryIsActive(e, at) {
+			continue
+		}
+		_, end, _ := parseEntryWindow(e)
+		if !found || end.Before(next) {
+			next, found = end, true
+		}
+	}
+	return next, found
+}
+
+// detectOverlaps reports every pair of entries whose [start, end) windows
+// intersect. Entries that merely touch (one ends as the next starts) are a
+// clean handoff and are not reported. Entries with unparseable times are skipped.
+func detectOverlaps(entries []incidentio.ScheduleEntry) []overlapPair {
+	type window struct {
+		entry      incidentio.ScheduleEntry
+		start, end time.Time
+	}
+	var windows []window
+	for _, e := range entries {
+		start, end, ok := parseEntryWindow(e)
+		if !ok {
+			continue
+		}
+		windows = append(windows, window{entry: e, start: start, end: end})
+	}
+
+	var pairs []overlapPair
+	for i := 0; i < len(windows); i++ {
+		for j := i + 1; j < len(windows); j++ {
+			a, b := windows[i], windows[j]
+			if !a.start.Before(b.end) || !b.start.Before(a.end) {
+				continue
+			}
+			start, end := a.start, a.end
+			if b.start.After(start) {
+				start = b.start
+			}
+			if b.end.Before(end) {
+				end = b.end
+			}
+			pairs = append(pairs, overlapPair{First: a.entry, Second: b.entry, Start: start, End: end})
+		}
+	}
+	return pairs
+}
+
+// sortEntriesByStart sorts entries in place by parsed start time, so offsets
+// like +09:00 and Z compare as instants. The sort is stable, and entries whose
+// start can't be parsed go last.
+func sortEntriesByStart(entries []incidentio.ScheduleEntry) {
+	sortEntriesByTime(entries, func(e incidentio.ScheduleEntry) string { return e.StartAt })
+}
+
+// sortEntriesByEnd is sortEntriesByStart keyed on end time.
+func sortEntriesByEnd(entries []incidentio.ScheduleEntry) {
+	sortEntriesByTime(entries, func(e incidentio.ScheduleEntry) string { return e.EndAt })
+}
+
+func sortEntriesByTime(entries []incidentio.ScheduleEntry, field func(incidentio.ScheduleEntry) string) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, errA := time.Parse(time.RFC3339, field(entries[i]))
+		b, errB := time.Parse(time.RFC3339, field(entries[j]))
+		if errA != nil || errB != nil {
+			return errA == nil && errB != nil
+		}
+		return a.Before(b)
+	})
+}
+
+// findCoverageGaps returns the parts of [windowStart, windowEnd) not covered
+// by any entry, in order. Entries with unparseable times cover nothing.
+func findCoverageGaps(entries []incidentio.ScheduleEntry, windowStart, windowEnd time.Time) []coverageGap {
+	type window struct{ start, end time.Time }
+	var windows []window
+	for _, e := range entries {
+		start, end, ok := parseEntryWindow(e)
+		if !ok || !start.Before(windowEnd) || !end.After(windowStart) {
+			continue
+		}
+		windows = append(windows, window{start: start, end: end})
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].start.Before(windows[j].start) })
+
+	var gaps []coverageGap
+	covered := windowStart
+	for _, w := range windows {
+		if w.start.After(covered) {
+			gaps = append(gaps, coverageGap{Start: covered, End: w.start})
+		}
+		if w.end.After(covered) {
+			covered = w.end
+		}
+	}
+	if covered.Before(windowEnd) {
+		gaps = append(gaps, coverageGap{Start: covered, End: windowEnd})
+	}
+	return gaps
+}
+
+// coverageFraction returns the fraction of [windowStart, windowEnd) covered by
+// at least one entry, from 0 to 1, for SLA reporting. Overlapping entries are
+// counted once. An empty or inverted window reports 0.
+func coverageFraction(entries []incidentio.ScheduleEntry, windowStart, windowEnd time.Time) float64 {
+	total := windowEnd.Sub(windowStart)
+	if total <= 0 {
+		return 0
+	}
+	uncovered := time.Duration(0)
+	for _, g := range findCoverageGaps(entries, windowStart, windowEnd) {
+		uncovered += g.End.Sub(g.Start)
+	}
+	return 1 - float64(uncovered)/float64(total)
+}
+
+// coalesceEntriesByUser merges each user's overlapping or adjacent entries
+// (e.g. from several schedule layers) into minimal spans. A merged span keeps
+// the fields of its earliest entry, with times formatted as UTC RFC3339.
+// Entries without a user (unfilled shifts) are never merged with each other.
+// Results are ordered by start, then user ID; invalid entries are appended
+// unchanged.
+func coalesceEntriesByUser(entries []incidentio.ScheduleEntry) []incidentio.ScheduleEntry {
+	type span struct {
+		entry      incidentio.ScheduleEntry
+		start, end time.Time
+	}
+	var spans []span
+	var invalid []incidentio.ScheduleEntry
+	for _, e := range entries {
+		start, end, ok := parseEntryWindow(e)
+		if !ok {
+			invalid = append(invalid, e)
+			continue
+		}
+		spans = append(spans, span{entry: e, start: start, end: end})
+	}
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].start.Before(spans[j].start) })
+
+	var merged []span
+	open := make(map[string]int) // user ID -> index of their latest span in merged
+	for _, s := range spans {
+		if entryHasUser(s.entry) {
+			if i, ok := open[s.entry.User.ID]; ok && !s.start.After(merged[i].end) {
+				if s.end.After(merged[i].end) {
+					merged[i].end = s.end
+				}
+				continue
+			}
+			open[s.entry.User.ID] = len(merged)
+		}
+		merged = append(merged, s)
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if !merged[i].start.Equal(merged[j].start) {
+			return merged[i].start.Before(merged[j].start)
+		}
+		return merged[i].entry.User.ID < merged[j].entry.User.ID
+	})
+
+	result := make([]incidentio.ScheduleEntry, 0, len(merged)+len(invalid))
+	for _, s := range merged {
+		e := s.entry
+		e.StartAt = s.start.UTC().Format(time.RFC3339)
+		e.EndAt = s.end.UTC().Format(time.RFC3339)
+		result = append(result, e)
+	}
+	return append(result, invalid...)
+}
+
+// normalizeEntries returns a copy of entries with start/end re-formatted as UTC
+// RFC3339, so string comparisons downstream compare the same instant. Times
+// that fail to parse are left untouched.
+func normalizeEntries(entries []incidentio.ScheduleEntry) []incidentio.ScheduleEntry {
+	normalized := make([]incidentio.ScheduleEntry, len(entries))
+	for i, e := range entries {
+		if start, err := time.Parse(time.RFC3339, e.StartAt); err == nil {
+			e.StartAt = start.UTC().Format(time.RFC3339)
+		}
+		if end, err := time.Parse(time.RFC3339, e.EndAt); err == nil {
+			e.EndAt = end.UTC().Format(time.RFC3339)
+		}
+		normalized[i] = e
+	}
+	return normalized
+}
+
+// ============================================================================
+// ON-CALL HELPER Tests
+// ============================================================================
+
+func TestONCALL_DetectOverlaps(t *testing.T) {
+	base := time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)
+	entry := func(id, userID string, start, end time.Time) incidentio.ScheduleEntry {
+		return incidentio.ScheduleEntry{
+			EntryID:    id,
+			ScheduleID: "sched-001",
+			StartAt:    start.Format(time.RFC3339),
+			EndAt:      end.Format(time.RFC3339),
+			User:       incidentio.User{ID: userID},
+		}
+	}
+
+	// Day shift 08:00-17:00 and night shift 16:00-01:00 overlap for one hour (handoff)
+	overlapping := []incidentio.ScheduleEntry{
+		entry("entry-day", "user-day", base, base.Add(9*time.Hour)),
+		entry("entry-night", "user-night", base.Add(8*time.Hour), base.Add(17*time.Hour)),
+	}
+	pairs := detectOverlaps(overlapping)
+	if len(pairs) != 1 {
+		t.Fatalf("ONCALL-OVERLAP FAIL: Expected 1 overlap pair, got %d", len(pairs))
+	}
+	if pairs[0].First.EntryID != "entry-day" || pairs[0].Second.EntryID != "entry-night" {
+		t.Errorf("ONCALL-OVERLAP FAIL: Wrong entries paired: %s / %s", pairs[0].First.EntryID, pairs[0].Second.EntryID)
+	}
+	if got := pairs[0].End.Sub(pairs[0].Start); got != time.Hour {
+		t.Errorf("ONCALL-OVERLAP FAIL: Expected 1h overlap window, got %v", got)
+	}
+
+	// Back-to-back shifts touch at 17:00 but do not overlap
+	adjacent := []incidentio.ScheduleEntry{
+		entry("entry-day", "user-day", base, base.Add(9*time.Hour)),
+		entry("entry-night", "user-night", base.Add(9*time.Hour), base.Add(17*time.Hour)),
+	}
+	if pairs := detectOverlaps(adjacent); len(pairs) != 0 {
+		t.Fatalf("ONCALL-OVERLAP FAIL: Expected no overlaps for back-to-back shifts, got %d", len(pairs))
+	}
+
+	t.Log("ONCALL-OVERLAP PASS: Overlapping handoff reported once, back-to-back shifts report none")
+}
+
+func TestONCALL_NormalizeEntries(t *testing.T) {
+	entries := []incidentio.ScheduleEntry{
+		{EntryID: "entry-tokyo", StartAt: "2026-01-01T09:00:00+09:00", EndAt: "2026-01-01T18:00:00+09:00"},
+		{EntryID: "entry-bad", StartAt: "not-a-time", EndAt: "2026-01-01T00:00:00Z"},
+	}
+
+	normalized := normalizeEntries(entries)
+
+	if normalized[0].StartAt != "2026-01-01T00:00:00Z" || normalized[0].EndAt != "2026-01-01T09:00:00Z" {
+		t.Fatalf("ONCALL-NORMALIZE FAIL: Expected 00:00Z-09:00Z, got %s-%s", normalized[0].StartAt, normalized[0].EndAt)
+	}
+	if normalized[1].StartAt != "not-a-time" {
+		t.Errorf("ONCALL-NORMALIZE FAIL: Unparseable start should be left as-is, got %q", normalized[1].StartAt)
+	}
+	if entries[0].StartAt != "2026-01-01T09:00:00+09:00" {
+		t.Error("ONCALL-NORMALIZE FAIL: Input entries were modified")
+	}
+
+	t.Logf("ONCALL-NORMALIZE PASS: +09:00 entry normalized to %s", normalized[0].StartAt)
+}
+
+func TestONCALL_EntryIsActive(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	entry := incidentio.ScheduleEntry{
+		EntryID: "entry-now",
+		StartAt: now.Add(-1 * time.Hour).Format(time.RFC3339),
+		EndAt:   now.Add(7 * time.Hour).Format(time.RFC3339),
+	}
+
+	if !entryValid(entry) {
+		t.Fatal("ONCALL-ACTIVE FAIL: Well-formed entry reported invalid")
+	}
+	if !entryIsActive(entry, now) {
+		t.Error("ONCALL-ACTIVE FAIL: Entry covering now should be active")
+	}
+	if entryIsActive(entry, now.AddDate(1, 0, 0)) {
+		t.Error("ONCALL-ACTIVE FAIL: Entry should not be active a year from now")
+	}
+	if entryIsActive(entry, now.Add(7*time.Hour)) {
+		t.Error("ONCALL-ACTIVE FAIL: End time is exclusive")
+	}
+
+	bad := incidentio.ScheduleEntry{EntryID: "entry-bad", StartAt: "garbage", EndAt: entry.EndAt}
+	if entryValid(bad) || entryIsActive(bad, now) {
+		t.Error("ONCALL-ACTIVE FAIL: Unparseable entry must be invalid and inactive")
+	}
+
+	t.Log("ONCALL-ACTIVE PASS: Active now, inactive in a year, unparseable entries rejected")
+}
+
+func TestONCALL_NextHandoff(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	entries := []incidentio.ScheduleEntry{
+		{EntryID: "entry-primary", StartAt: now.Add(-4 * time.Hour).Format(time.RFC3339), EndAt: now.Add(6 * time.Hour).Format(time.RFC3339)},
+		{EntryID: "entry-secondary", StartAt: now.Add(-1 * time.Hour).Format(time.RFC3339), EndAt: now.Add(2 * time.Hour).Format(time.RFC3339)},
+		// Ends soonest but isn't active yet, so it must not be picked
+		{EntryID: "entry-future", StartAt: now.Add(30 * time.Minute).Format(time.RFC3339), EndAt: now.Add(time.Hour).Format(time.RFC3339)},
+	}
+
+	next, ok := nextHandoff(entries, now)
+	if !ok {
+		t.Fatal("ONCALL-HANDOFF FAIL: Expected a handoff while entries are active")
+	}
+	if want := now.Add(2 * time.Hour); !next.Equal(want) {
+		t.Fatalf("ONCALL-HANDOFF FAIL: Expected nearer end %s, got %s", want, next)
+	}
+
+	if _, ok := nextHandoff(entries, now.Add(24*time.Hour)); ok {
+		t.Error("ONCALL-HANDOFF FAIL: No entries are active tomorrow, expected ok=false")
+	}
+
+	t.Logf("ONCALL-HANDOFF PASS: Next handoff at %s", next.Format(time.RFC3339))
+}
+
+func TestONCALL_SortEntries(t *testing.T) {
+	entries := []incidentio.ScheduleEntry{
+		{EntryID: "entry-c", StartAt: "2026-01-01T16:00:00Z", EndAt: "2026-01-02T00:00:00Z"},
+		{EntryID: "entry-bad", StartAt: "garbage", EndAt: "garbage"},
+		{EntryID: "entry-a", StartAt: "2026-01-01T08:00:00+09:00", EndAt: "2026-01-01T20:00:00Z"}, // 2025-12-31T23:00Z
+		{EntryID: "entry-b1", StartAt: "2026-01-01T08:00:00Z", EndAt: "2026-01-01T12:00:00Z"},
+		{EntryID: "entry-b2", StartAt: "2026-01-01T08:00:00Z", EndAt: "2026-01-01T10:00:00Z"},
+	}
+	ids := func() string {
+		var out []string
+		for _, e := range entries {
+			out = append(out, e.EntryID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	sortEntriesByStart(entries)
+	if got := ids(); got != "entry-a,entry-b1,entry-b2,entry-c,entry-bad" {
+		t.Fatalf("ONCALL-SORT FAIL: By start: got %s", got)
+	}
+
+	sortEntriesByEnd(entries)
+	if got := ids(); got != "entry-b2,entry-b1,entry-a,entry-c,entry-bad" {
+		t.Fatalf("ONCALL-SORT FAIL: By end: got %s", got)
+	}
+
+	t.Log("ONCALL-SORT PASS: Entries ordered by parsed instant, ties stable, unparseable last")
+}
+
+func TestONCALL_FindCoverageGaps(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []incidentio.ScheduleEntry{
+		// Listed out of order; 12:00-13:00 is uncovered
+		{EntryID: "entry-pm", StartAt: day.Add(13 * time.Hour).Format(time.RFC3339), EndAt: day.Add(24 * time.Hour).Format(time.RFC3339)},
+		{EntryID: "entry-am", StartAt: day.Format(time.RFC3339), EndAt: day.Add(12 * time.Hour).Format(time.RFC3339)},
+	}
+
+	gaps := findCoverageGaps(entries, day, day.Add(24*time.Hour))
+	if len(gaps) != 1 {
+		t.Fatalf("ONCALL-GAPS FAIL: Expected exactly 1 gap, got %d: %+v", len(gaps), gaps)
+	}
+	if !gaps[0].Start.Equal(day.Add(12*time.Hour)) || !gaps[0].End.Equal(day.Add(13*time.Hour)) {
+		t.Fatalf("ONCALL-GAPS FAIL: Expected 12:00-13:00, got %s-%s", gaps[0].Start, gaps[0].End)
+	}
+
+	// A window extending past the last entry reports the uncovered tail too
+	if gaps := findCoverageGaps(entries, day, day.Add(26*time.Hour)); len(gaps) != 2 || !gaps[1].Start.Equal(day.Add(24*time.Hour)) {
+		t.Errorf("ONCALL-GAPS FAIL: Expected the 24:00-26:00 tail as a second gap, got %+v", gaps)
+	}
+
+	t.Logf("ONCALL-GAPS PASS: Gap %s-%s reported", gaps[0].Start.Format("15:04"), gaps[0].End.Format("15:04"))
+}
+
+func TestONCALL_CoalesceEntriesByUser(t *testing.T) {
+	entries := []incidentio.ScheduleEntry{
+		// Alice: adjacent entries from two layers merge into 08:00-20:00
+		{EntryID: "layer2-alice", StartAt: "2026-01-01T14:00:00Z", EndAt: "2026-01-01T20:00:00Z", User: incidentio.User{ID: "user-alice"}},
+		{EntryID: "layer1-alice", StartAt: "2026-01-01T08:00:00Z", EndAt: "2026-01-01T14:00:00Z", User: incidentio.User{ID: "user-alice"}},
+		// Bob overlaps Alice but is a different user, so stays separate
+		{EntryID: "layer1-bob", StartAt: "2026-01-01T12:00:00Z", EndAt: "2026-01-01T18:00:00Z", User: incidentio.User{ID: "user-bob"}},
+		// Alice again after a gap: a second span
+		{EntryID: "layer1-alice-late", StartAt: "2026-01-01T22:00:00Z", EndAt: "2026-01-01T23:00:00Z", User: incidentio.User{ID: "user-alice"}},
+		// Adjacent unfilled shifts are not one person's span, so stay separate
+		{EntryID: "unfilled-early", StartAt: "2026-01-01T00:00:00Z", EndAt: "2026-01-01T04:00:00Z"},
+		{EntryID: "unfilled-late", StartAt: "2026-01-01T04:00:00Z", EndAt: "2026-01-01T08:00:00Z"},
+	}
+
+	coalesced := coalesceEntriesByUser(entries)
+
+	var got []string
+	for _, e := range coalesced {
+		got = append(got, e.EntryID+"="+e.StartAt+"/"+e.EndAt)
+	}
+	want := []string{
+		"unfilled-early=2026-01-01T00:00:00Z/2026-01-01T04:00:00Z",
+		"unfilled-late=2026-01-01T04:00:00Z/2026-01-01T08:00:00Z",
+		"layer1-alice=2026-01-01T08:00:00Z/2026-01-01T20:00:00Z",
+		"layer1-bob=2026-01-01T12:00:00Z/2026-01-01T18:00:00Z",
+		"layer1-alice-late=2026-01-01T22:00:00Z/2026-01-01T23:00:00Z",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("ONCALL-COALESCE FAIL:\ngot  %v\nwant %v", got, want)
+	}
+
+	t.Logf("ONCALL-COALESCE PASS: %d entries coalesced into %d spans", len(entries), len(coalesced))
+}
+
+func TestONCALL_EntryWithoutUser(t *testing.T) {
+	raw := `[
+		{"entry_id": "e1", "schedule_id": "sched-001", "start_at": "2026-01-01T00:00:00Z", "end_at": "2026-01-01T08:00:00Z", "user": null},
+		{"entry_id": "e2", "schedule_id": "sched-001", "start_at": "2026-01-01T08:00:00Z", "end_at": "2026-01-01T16:00:00Z"},
+		{"entry_id": "e3", "schedule_id": "sched-001", "start_at": "2026-01-01T16:00:00Z", "end_at": "2026-01-02T00:00:00Z", "user": {"id": "user-1", "name": "User One"}}
+	]`
+	var entries []incidentio.ScheduleEntry
+	if err := json.Unmarshal([]byte(raw), &entries); err != nil {
+		t.Fatalf("ONCALL-NO-USER FAIL: Entry with null user should decode: %v", err)
+	}
+
+	for i, want := range []bool{false, false, true} {
+		if got := entryHasUser(entries[i]); got != want {
+			t.Errorf("ONCALL-NO-USER FAIL: %s: entryHasUser = %v, want %v", entries[i].EntryID, got, want)
+		}
+	}
+
+	t.Log("ONCALL-NO-USER PASS: Null and absent user objects decode to entries without a user")
+}
+
+func TestONCALL_CoverageFraction(t *testing.T) {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := func(id string, from, to time.Duration) incidentio.ScheduleEntry {
+		return incidentio.ScheduleEntry{EntryID: id, StartAt: day.Add(from).Format(time.RFC3339), EndAt: day.Add(to).Format(time.RFC3339)}
+	}
+	window := 24 * time.Hour
+
+	for _, tc := range []struct {
+		name    string
+		entries []incidentio.ScheduleEntry
+		want    float64
+	}{
+		{"half", []incidentio.ScheduleEntry{entry("am", 0, 12*time.Hour)}, 0.5},
+		{"overlap counted once", []incidentio.ScheduleEntry{entry("a", 0, 8*time.Hour), entry("b", 4*time.Hour, 12*time.Hour)}, 0.5},
+		{"spills past window", []incidentio.ScheduleEntry{entry("late", 18*time.Hour, 30*time.Hour)}, 0.25},
+		{"full", []incidentio.ScheduleEntry{entry("all", -time.Hour, 25*time.Hour)}, 1},
+		{"none", nil, 0},
+	} {
+		if got := coverageFraction(tc.entries, day, day.Add(window)); math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("ONCALL-COVERAGE FAIL: %s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+	if got := coverageFraction([]incidentio.ScheduleEntry{entry("am", 0, 12*time.Hour)}, day, day); got != 0 {
+		t.Errorf("ONCALL-COVERAGE FAIL: Empty window should report 0, got %v", got)
+	}
+
+	t.Log("ONCALL-COVERAGE PASS: Coverage fraction matches covered share of the window")
+}
+
+func TestONCALL_FilterEntriesToWindow(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// As returned by a backend that ignores the requested window
+	entries := []incidentio.ScheduleEntry{
+		{EntryID: "entry-current", StartAt: now.Add(-time.Hour).Format(time.RFC3339), EndAt: now.Add(time.Hour).Format(time.RFC3339)},
+		{EntryID: "entry-last-week", StartAt: now.Add(-7 * 24 * time.Hour).Format(time.RFC3339), EndAt: now.Add(-6 * 24 * time.Hour).Format(time.RFC3339)},
+		{EntryID: "entry-ends-at-start", StartAt: now.Add(-time.Hour).Format(time.RFC3339), EndAt: now.Format(time.RFC3339)},
+		{EntryID: "entry-bad", StartAt: "garbage", EndAt: now.Add(time.Hour).Format(time.RFC3339)},
+	}
+
+	kept := filterEntriesToWindow(entries, now, now.Add(time.Minute))
+	if len(kept) != 1 || kept[0].EntryID != "entry-current" {
+		t.Fatalf("ONCALL-WINDOW FAIL: Expected only entry-current, got %+v", kept)
+	}
+
+	t.Logf("ONCALL-WINDOW PASS: %d of %d entries kept for the requested window", len(kept), len(entries))
+}
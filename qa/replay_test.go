@@ -0,0 +1,193 @@
+package qa
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	incidentio "github.com/strongdm/web/pkg/incidentio/sdk"
+)
+
+// ============================================================================
+// Helper: record/replay transports for reproducing production issues
+// ============================================================================
+
+// recordedExchange is one request/response pair, written as a JSON line by
+// recordingTransport and read back by replayTransport.
+type recordedExchange struct {
+	Method          string      `json:"method"`
+	Path            string      `json:"path"`
+	Query           string      `json:"query,omitempty"`
+	RequestHeaders  http.Header `json:"request_headers"`
+	Status          int         `json:"status"`
+	ResponseHeaders http.Header `json:"response_headers"`
+	ResponseBody    string      `json:"response_body"`
+}
+
+// recordingTransport passes requests through to next and writes each exchange
+// to w as a JSON line. The Authorization header is redacted.
+type recordingTransport struct {
+	next http.RoundTripper
+	mu   sync.Mutex
+	enc  *json.Encoder
+}
+
+func newRecordingTransport(w io.Writer, next http.RoundTripper) *recordingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &recordingTransport{next: next, enc: json.NewEncoder(w)}
+}
+
+func (t *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	headers := r.Header.Clone()
+	if headers.Get("Authorization") != "" {
+		headers.Set("Authorization", "REDACTED")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.enc.Encode(recordedExchange{
+		Method:          r.Method,
+		Path:            r.URL.Path,
+		Query:           r.URL.RawQuery,
+		RequestHeaders:  headers,
+		Status:          resp.StatusCode,
+		ResponseHeaders: resp.Header,
+		ResponseBody:    string(body),
+	}); err != nil {
+		return nil, fmt.Errorf("record %s %s: %w", r.Method, r.URL.Path, err)
+	}
+	return resp, nil
+}
+
+// replayTransport serves recorded exchanges in order without a live server.
+// Each request must match the next exchange's method and path; query strings
+// are not compared because sync entry windows depend on the current time.
+type replayTransport struct {
+	mu        sync.Mutex
+	exchanges []recordedExchange
+}
+
+func newReplayTransport(r io.Reader) (*replayTransport, error) {
+	t := &replayTransport{}
+	dec := json.NewDecoder(r)
+	for {
+		var ex recordedExchange
+		if err := dec.Decode(&ex); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("read recording: %w", err)
+		}
+		t.exchanges = append(t.exchanges, ex)
+	}
+	return t, nil
+}
+
+func (t *replayTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.exchanges) == 0 {
+		return nil, fmt.Errorf("replay: no recorded response left for %s %s", r.Method, r.URL.Path)
+	}
+	ex := t.exchanges[0]
+	if ex.Method != r.Method || ex.Path != r.URL.Path {
+		return nil, fmt.Errorf("replay: got %s %s, recording expects %s %s", r.Method, r.URL.Path, ex.Method, ex.Path)
+	}
+	t.exchanges = t.exchanges[1:]
+	return &http.Response{
+		StatusCode: ex.Status,
+		Status:     fmt.Sprintf("%d %s", ex.Status, http.StatusText(ex.Status)),
+		Header:     ex.ResponseHeaders,
+		Body:       io.NopCloser(strings.NewReader(ex.ResponseBody)),
+		Request:    r,
+	}, nil
+}
+
+// remaining reports how many recorded exchanges have not been replayed.
+func (t *replayTransport) remaining() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.exchanges)
+}
+
+// ============================================================================
+// REPLAY Tests
+// ============================================================================
+
+func TestREPLAY_RecordAndReplaySync(t *testing.T) {
+	mock := newMockIncidentIO("test-key")
+	mock.addSchedule("sched-001", "Primary On-Call", "UTC")
+	mock.addSchedule("sched-002", "Secondary On-Call", "Europe/London")
+	mock.addUser("user-1", "User One", "one@example.com", "responder")
+	mock.addUser("user-2", "User Two", "two@example.com", "responder")
+	mock.setOnCall("sched-001", []string{"user-1", "user-2"})
+	mock.setOnCall("sched-002", []string{"user-2"})
+	tracked := []string{"sched-001", "sched-002"}
+
+	srv := mock.serve()
+	var recording bytes.Buffer
+	recordClient := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: newRecordingTransport(&recording, nil)}),
+	)
+	recorded, err := simulateFullSync(context.Background(), recordClient, tracked)
+	if err != nil {
+		t.Fatalf("REPLAY FAIL: Recording sync: %v", err)
+	}
+	srv.Close()
+
+	if strings.Contains(recording.String(), "test-key") {
+		t.Fatal("REPLAY FAIL: API key leaked into the recording")
+	}
+
+	replay, err := newReplayTransport(bytes.NewReader(recording.Bytes()))
+	if err != nil {
+		t.Fatalf("REPLAY FAIL: %v", err)
+	}
+	recordedCount := replay.remaining()
+
+	// The mock is closed; the replay client never touches the network
+	replayClient := incidentio.NewClient("test-key",
+		incidentio.WithBaseURL(srv.URL),
+		incidentio.WithHTTPClient(&http.Client{Transport: replay}),
+	)
+	replayed, err := simulateFullSync(context.Background(), replayClient, tracked)
+	if err != nil {
+		t.Fatalf("REPLAY FAIL: Replayed sync: %v", err)
+	}
+
+	// Timing legitimately differs between runs
+	for _, results := range [][]syncResult{recorded, replayed} {
+		for i := range results {
+			results[i].StartedAt, results[i].Duration = time.Time{}, 0
+		}
+	}
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Fatalf("REPLAY FAIL: Replayed results differ:\nrecorded: %+v\nreplayed: %+v", recorded, replayed)
+	}
+	if n := replay.remaining(); n != 0 {
+		t.Errorf("REPLAY FAIL: %d recorded exchanges were not replayed", n)
+	}
+
+	t.Logf("REPLAY PASS: %d exchanges recorded and replayed with identical sync results", recordedCount)
+}